@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// serverNote is one operator annotation on a server, newest last.
+type serverNote struct {
+	Text   string `json:"text"`
+	Author string `json:"author,omitempty"`
+	At     string `json:"at,omitempty"`
+}
+
+// latestNote returns the newest annotation text, or "".
+func latestNote(notes []serverNote) string {
+	if len(notes) == 0 {
+		return ""
+	}
+	return notes[len(notes)-1].Text
+}
+
+var annotateClearFlag bool
+
+var annotateCmd = &cobra.Command{
+	Use:   "annotate <server> [note]",
+	Short: "Attach a free-text note to a server",
+	Long: `Appends an operator note to a server's registry entry, e.g.
+"flaky after v2.1, pin to v2.0". Notes show up in the detail view and in
+'server list --wide'. --clear removes all notes.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		if annotateClearFlag {
+			if len(args) > 1 {
+				return fmt.Errorf("--clear takes no note text")
+			}
+			return mutateServer(path, args[0], func(srv *MCPServer) error {
+				srv.Notes = nil
+				return nil
+			})
+		}
+		if len(args) != 2 || strings.TrimSpace(args[1]) == "" {
+			return fmt.Errorf("annotate needs a note, or --clear")
+		}
+		return mutateServer(path, args[0], func(srv *MCPServer) error {
+			srv.Notes = append(srv.Notes, serverNote{
+				Text:   args[1],
+				Author: currentActor(),
+				At:     time.Now().UTC().Format(time.RFC3339),
+			})
+			return nil
+		})
+	},
+}
+
+func init() {
+	annotateCmd.Flags().BoolVar(&annotateClearFlag, "clear", false, "remove all notes from the server")
+	rootCmd.AddCommand(annotateCmd)
+}
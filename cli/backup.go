@@ -0,0 +1,239 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Backup archives are tarballs whose entries use logical prefixes, so a
+// restore on another machine maps them back to that machine's paths:
+//
+//	registry/mcp_status.json    the registry file
+//	state/managed_servers.json  managed process state
+//	history/<n>.json            undo snapshots
+//	config/config.json          the devgen config file
+const backupPrefix = "devgen-backup-"
+
+var (
+	backupToFlag   string
+	backupKeepFlag int
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Archive the registry, config, and history",
+	Long: `Writes a compressed archive of the registry file, managed process
+state, undo history, and devgen config to --to (default: backups/ in the
+machina root), pruning old archives beyond --keep.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if strings.HasPrefix(backupToFlag, "s3://") {
+			return fmt.Errorf("s3 destinations are not supported by this build: back up to a directory and sync it separately")
+		}
+		regPath, err := registryPath()
+		if err != nil {
+			return err
+		}
+		target := backupToFlag
+		if target == "" {
+			root, err := findMachinaRoot()
+			if err != nil {
+				return err
+			}
+			target = filepath.Join(root, "backups")
+		}
+		if err := os.MkdirAll(target, 0o755); err != nil {
+			return err
+		}
+		name := backupPrefix + time.Now().UTC().Format("20060102-150405") + ".tar.gz"
+		archivePath := filepath.Join(target, name)
+		entries, err := writeBackupArchive(archivePath, regPath)
+		if err != nil {
+			os.Remove(archivePath)
+			return err
+		}
+		fmt.Printf("Backed up %d file(s) to %s\n", entries, archivePath)
+		return pruneBackups(target, backupKeepFlag)
+	},
+}
+
+// backupSources lists the files to archive with their logical names.
+func backupSources(regPath string) (map[string]string, error) {
+	sources := map[string]string{}
+	sources["registry/"+filepath.Base(regPath)] = regPath
+	if statePath, err := managedStatePath(); err == nil {
+		sources["state/"+filepath.Base(statePath)] = statePath
+	}
+	if configPath, err := configFilePath(); err == nil {
+		sources["config/config.json"] = configPath
+	}
+	names, err := snapshotNames(regPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		sources["history/"+name] = filepath.Join(historyDir(regPath), name)
+	}
+	return sources, nil
+}
+
+func writeBackupArchive(archivePath, regPath string) (int, error) {
+	sources, err := backupSources(regPath)
+	if err != nil {
+		return 0, err
+	}
+	f, err := os.Create(archivePath)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	defer gz.Close()
+	tw := tar.NewWriter(gz)
+	defer tw.Close()
+
+	count := 0
+	for _, logical := range sortedKeys(sources) {
+		data, err := os.ReadFile(sources[logical])
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return 0, err
+		}
+		header := &tar.Header{
+			Name:    logical,
+			Mode:    0o644,
+			Size:    int64(len(data)),
+			ModTime: time.Now(),
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return 0, err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return 0, err
+		}
+		count++
+	}
+	if count == 0 {
+		return 0, fmt.Errorf("nothing to back up: no registry, state, or config files found")
+	}
+	return count, nil
+}
+
+// pruneBackups removes the oldest archives beyond keep.
+func pruneBackups(dir string, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	var names []string
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), backupPrefix) && strings.HasSuffix(entry.Name(), ".tar.gz") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	for len(names) > keep {
+		if err := os.Remove(filepath.Join(dir, names[0])); err != nil {
+			return err
+		}
+		debugf("backup: pruned %s", names[0])
+		names = names[1:]
+	}
+	return nil
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <backup>",
+	Short: "Restore registry, config, and history from a backup archive",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		regPath, err := registryPath()
+		if err != nil {
+			return err
+		}
+		if err := confirmAction(fmt.Sprintf("overwrite current registry and config from %s", args[0])); err != nil {
+			return err
+		}
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("reading backup %s: %w", args[0], err)
+		}
+		tr := tar.NewReader(gz)
+		restored := 0
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("reading backup %s: %w", args[0], err)
+			}
+			dest, err := restoreDestination(header.Name, regPath)
+			if err != nil {
+				fmt.Printf("Skipping %s: %v\n", header.Name, err)
+				continue
+			}
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return err
+			}
+			if dryRunFlag {
+				fmt.Printf("dry-run: would restore %s to %s\n", header.Name, dest)
+				continue
+			}
+			if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(dest, data, 0o644); err != nil {
+				return err
+			}
+			restored++
+		}
+		fmt.Printf("Restored %d file(s) from %s\n", restored, args[0])
+		return nil
+	},
+}
+
+// restoreDestination maps a logical archive name to this machine's path.
+func restoreDestination(logical, regPath string) (string, error) {
+	prefix, name, ok := strings.Cut(filepath.ToSlash(logical), "/")
+	if !ok || strings.Contains(name, "/") || strings.Contains(name, "..") {
+		return "", fmt.Errorf("unrecognized archive entry")
+	}
+	switch prefix {
+	case "registry":
+		return regPath, nil
+	case "state":
+		return managedStatePath()
+	case "config":
+		return configFilePath()
+	case "history":
+		return filepath.Join(historyDir(regPath), name), nil
+	default:
+		return "", fmt.Errorf("unrecognized archive entry")
+	}
+}
+
+func init() {
+	backupCmd.Flags().StringVar(&backupToFlag, "to", "", "destination directory (default: backups/ in the machina root)")
+	backupCmd.Flags().IntVar(&backupKeepFlag, "keep", 10, "number of backups to retain (0 keeps all)")
+	rootCmd.AddCommand(backupCmd, restoreCmd)
+}
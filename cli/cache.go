@@ -0,0 +1,114 @@
+package main
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// registryCache keeps a parsed registry in memory with indexes by name,
+// category, and status, so hot read paths (dashboard refresh, completion)
+// don't re-unmarshal the whole file on every call. The cache invalidates
+// itself whenever the file's size or mtime changes.
+type registryCache struct {
+	mu         sync.Mutex
+	path       string
+	modTime    time.Time
+	size       int64
+	reg        *MCPRegistry
+	byName     map[string]*MCPServer
+	byCategory map[string][]*MCPServer
+	byStatus   map[string][]*MCPServer
+}
+
+// defaultRegistryCache serves all cached reads in this process.
+var defaultRegistryCache registryCache
+
+// cachedRegistry returns the parsed registry for path, reloading only when
+// the file changed on disk. Callers must treat the result as read-only;
+// mutations go through mutateServer.
+func cachedRegistry(path string) (*MCPRegistry, error) {
+	return defaultRegistryCache.get(path)
+}
+
+// invalidateRegistryCache drops the cached registry for path. saveMCPRegistry
+// calls it after every successful write as an in-process write-completion
+// signal: the next cached read reloads from disk even on filesystems whose
+// mtime granularity is too coarse for the stat check to notice the change.
+func invalidateRegistryCache(path string) {
+	defaultRegistryCache.invalidate(path)
+}
+
+func (c *registryCache) invalidate(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.path == path {
+		c.reg = nil
+	}
+}
+
+func (c *registryCache) get(path string) (*MCPRegistry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if c.reg != nil && c.path == path && c.modTime.Equal(info.ModTime()) && c.size == info.Size() {
+		return c.reg, nil
+	}
+
+	reg, err := loadMCPRegistry(path)
+	if err != nil {
+		return nil, err
+	}
+	c.path = path
+	c.modTime = info.ModTime()
+	c.size = info.Size()
+	c.reg = reg
+	c.rebuildIndexes()
+	return reg, nil
+}
+
+func (c *registryCache) rebuildIndexes() {
+	c.byName = make(map[string]*MCPServer, len(c.reg.Servers))
+	c.byCategory = map[string][]*MCPServer{}
+	c.byStatus = map[string][]*MCPServer{}
+	for i := range c.reg.Servers {
+		srv := &c.reg.Servers[i]
+		c.byName[srv.Name] = srv
+		c.byCategory[srv.Category] = append(c.byCategory[srv.Category], srv)
+		c.byStatus[srv.Status] = append(c.byStatus[srv.Status], srv)
+	}
+}
+
+// cachedServer looks one server up by name through the index.
+func cachedServer(path, name string) (*MCPServer, error) {
+	if _, err := defaultRegistryCache.get(path); err != nil {
+		return nil, err
+	}
+	defaultRegistryCache.mu.Lock()
+	defer defaultRegistryCache.mu.Unlock()
+	return defaultRegistryCache.byName[name], nil
+}
+
+// cachedServersByCategory returns the indexed category bucket.
+func cachedServersByCategory(path, category string) ([]*MCPServer, error) {
+	if _, err := defaultRegistryCache.get(path); err != nil {
+		return nil, err
+	}
+	defaultRegistryCache.mu.Lock()
+	defer defaultRegistryCache.mu.Unlock()
+	return defaultRegistryCache.byCategory[category], nil
+}
+
+// cachedServersByStatus returns the indexed status bucket.
+func cachedServersByStatus(path, status string) ([]*MCPServer, error) {
+	if _, err := defaultRegistryCache.get(path); err != nil {
+		return nil, err
+	}
+	defaultRegistryCache.mu.Lock()
+	defer defaultRegistryCache.mu.Unlock()
+	return defaultRegistryCache.byStatus[status], nil
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTestRegistry writes a registry with n servers and returns its path.
+func writeTestRegistry(tb testing.TB, dir string, n int) string {
+	tb.Helper()
+	reg := &MCPRegistry{Version: "1.0.0"}
+	for i := 0; i < n; i++ {
+		reg.Servers = append(reg.Servers, MCPServer{
+			Name:     fmt.Sprintf("server-%04d", i),
+			Endpoint: fmt.Sprintf("stdio://devqai/machina/src/server_%04d.py", i),
+			Category: fmt.Sprintf("category-%d", i%7),
+			Status:   "active",
+			Tools:    []string{"tool_a", "tool_b"},
+			Enabled:  i%2 == 0,
+		})
+	}
+	path := filepath.Join(dir, registryFileName)
+	if err := saveMCPRegistry(path, reg); err != nil {
+		tb.Fatalf("writing test registry: %v", err)
+	}
+	return path
+}
+
+func TestCachedRegistryInvalidatesOnChange(t *testing.T) {
+	path := writeTestRegistry(t, t.TempDir(), 10)
+	defaultRegistryCache = registryCache{}
+
+	first, err := cachedRegistry(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := cachedRegistry(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != again {
+		t.Error("unchanged file should return the cached registry")
+	}
+
+	// Mutate the file; the cache must notice the new mtime/size.
+	if err := mutateServer(path, "server-0001", func(srv *MCPServer) error {
+		srv.Enabled = true
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// mtime granularity can be coarse; nudge it explicitly.
+	future := time.Now().Add(2 * time.Second)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := cachedRegistry(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded == first {
+		t.Error("changed file should invalidate the cache")
+	}
+	srv, err := cachedServer(path, "server-0001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if srv == nil || !srv.Enabled {
+		t.Error("index should reflect the reloaded registry")
+	}
+}
+
+func TestSaveInvalidatesRegistryCache(t *testing.T) {
+	path := writeTestRegistry(t, t.TempDir(), 3)
+	defaultRegistryCache = registryCache{}
+
+	if _, err := cachedRegistry(path); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Same-length mutation plus a pinned mtime simulates a slow filesystem
+	// where stat cannot distinguish the new file from the old one. Only the
+	// save-side invalidation makes the next read see the change.
+	if err := mutateServer(path, "server-0001", func(srv *MCPServer) error {
+		srv.Status = "locked"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, before.ModTime(), before.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+	after, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.Size() != before.Size() {
+		t.Fatalf("fixture drift: size changed %d -> %d, mutation must be same-length", before.Size(), after.Size())
+	}
+
+	reloaded, err := cachedRegistry(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if srv := reloaded.findServer("server-0001"); srv == nil || srv.Status != "locked" {
+		t.Error("cached read after save returned stale data")
+	}
+}
+
+func TestCachedIndexBuckets(t *testing.T) {
+	path := writeTestRegistry(t, t.TempDir(), 70)
+	defaultRegistryCache = registryCache{}
+
+	byCat, err := cachedServersByCategory(path, "category-3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byCat) != 10 {
+		t.Errorf("category-3 bucket: got %d servers, want 10", len(byCat))
+	}
+	byStatus, err := cachedServersByStatus(path, "active")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byStatus) != 70 {
+		t.Errorf("active bucket: got %d servers, want 70", len(byStatus))
+	}
+}
+
+func BenchmarkRegistryLookupUncached(b *testing.B) {
+	path := writeTestRegistry(b, b.TempDir(), 1500)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if reg.findServer("server-1250") == nil {
+			b.Fatal("server not found")
+		}
+	}
+}
+
+func BenchmarkRegistryLookupCached(b *testing.B) {
+	path := writeTestRegistry(b, b.TempDir(), 1500)
+	defaultRegistryCache = registryCache{}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		srv, err := cachedServer(path, "server-1250")
+		if err != nil {
+			b.Fatal(err)
+		}
+		if srv == nil {
+			b.Fatal("server not found")
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	callArgFlags  []string
+	callJSONFlag  string
+	callPlainFlag bool
+	callSaveFlag  string
+)
+
+var callCmd = &cobra.Command{
+	Use:   "call <server> <tool>",
+	Short: "Invoke a tool on a server and view the response",
+	Long: `Launches the server, performs the handshake, and invokes the named
+tool. Arguments come from repeated --arg key=value pairs (values are
+parsed as JSON when possible, otherwise taken as strings) or a single
+--json object, which wins when both are given.
+
+On a terminal the response opens in a foldable JSON viewer; piped or
+--plain output prints indented JSON for scripting.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		srv := reg.findServer(args[0])
+		if srv == nil {
+			return serverNotFoundError(args[0])
+		}
+
+		callArgs, err := parseCallArgs(callArgFlags, callJSONFlag)
+		if err != nil {
+			return err
+		}
+		client, err := dialStdioServer(srv)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		result, err := client.callWithRetry("tools/call", map[string]interface{}{
+			"name":      args[1],
+			"arguments": callArgs,
+		}, 30*time.Second)
+		if err != nil {
+			return fmt.Errorf("tools/call %s: %w", args[1], err)
+		}
+		if callSaveFlag != "" {
+			// Only successful invocations are worth replaying.
+			if err := savePreset(callSaveFlag, args[0], args[1], callArgs); err != nil {
+				return fmt.Errorf("saving preset: %w", err)
+			}
+			fmt.Fprintf(os.Stderr, "Saved preset %q; replay it with 'devgen run %s'\n", callSaveFlag, callSaveFlag)
+		}
+		return showCallResult(result)
+	},
+}
+
+// parseCallArgs builds the tools/call arguments object. --arg values that
+// parse as JSON keep their type (42, true, ["a"]); anything else is a
+// plain string, so --arg query=hello needs no extra quoting.
+func parseCallArgs(pairs []string, rawJSON string) (map[string]interface{}, error) {
+	if rawJSON != "" {
+		args := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(rawJSON), &args); err != nil {
+			return nil, fmt.Errorf("--json: %w", err)
+		}
+		return args, nil
+	}
+	args := map[string]interface{}{}
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("--arg %q: want key=value", pair)
+		}
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(value), &parsed); err == nil {
+			args[key] = parsed
+		} else {
+			args[key] = value
+		}
+	}
+	return args, nil
+}
+
+// showCallResult opens the interactive viewer on a terminal and prints
+// indented JSON otherwise.
+func showCallResult(result json.RawMessage) error {
+	if !callPlainFlag && term.IsTerminal(int(os.Stdout.Fd())) {
+		viewer, err := newJSONViewer(result)
+		if err == nil {
+			_, err = tea.NewProgram(viewer, tea.WithAltScreen()).Run()
+			return err
+		}
+		// Unparseable result: fall through and print it raw.
+		fmt.Fprintf(os.Stderr, "WARNING: %v\n", err)
+	}
+	var pretty json.RawMessage
+	if indented, err := json.MarshalIndent(json.RawMessage(result), "", "  "); err == nil {
+		pretty = indented
+	} else {
+		pretty = result
+	}
+	fmt.Println(string(pretty))
+	return nil
+}
+
+func init() {
+	callCmd.Flags().StringArrayVar(&callArgFlags, "arg", nil, "tool argument as key=value (repeatable)")
+	callCmd.Flags().StringVar(&callJSONFlag, "json", "", "tool arguments as a JSON object")
+	callCmd.Flags().BoolVar(&callPlainFlag, "plain", false, "print JSON instead of opening the viewer")
+	callCmd.Flags().StringVar(&callSaveFlag, "save", "", "save this invocation as a named preset after it succeeds")
+	rootCmd.AddCommand(callCmd)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// supportedProtocolVersions are the MCP protocol revisions devgen can talk
+// to, newest first.
+var supportedProtocolVersions = []string{
+	"2024-11-05",
+	"2024-10-07",
+}
+
+// knownCapabilities is the set of capability keys reported on, in display
+// order. Unknown keys advertised by the server are appended after these.
+var knownCapabilities = []string{"tools", "resources", "prompts", "sampling", "logging"}
+
+// printCapabilityReport renders the protocol/capability report for an
+// initialized client, flagging version mismatches with devgen.
+func printCapabilityReport(srv *MCPServer, client *mcpClient) {
+	init := client.initResult
+	if init == nil {
+		fmt.Println("Server did not return a parseable initialize result.")
+		return
+	}
+
+	fmt.Printf("Server:           %s\n", srv.Name)
+	if init.ServerInfo.Name != "" {
+		fmt.Printf("Implementation:   %s %s\n", init.ServerInfo.Name, init.ServerInfo.Version)
+	}
+	fmt.Printf("Protocol version: %s", init.ProtocolVersion)
+	if supportsProtocolVersion(init.ProtocolVersion) {
+		fmt.Printf(" (supported by devgen)\n")
+	} else {
+		fmt.Printf(" (MISMATCH: devgen supports %v)\n", supportedProtocolVersions)
+	}
+
+	fmt.Println("\nCapabilities:")
+	seen := map[string]bool{}
+	for _, key := range knownCapabilities {
+		seen[key] = true
+		if _, ok := init.Capabilities[key]; ok {
+			fmt.Printf("  %-12s advertised\n", key)
+		} else {
+			fmt.Printf("  %-12s not advertised\n", key)
+		}
+	}
+	var extra []string
+	for key := range init.Capabilities {
+		if !seen[key] {
+			extra = append(extra, key)
+		}
+	}
+	sort.Strings(extra)
+	for _, key := range extra {
+		fmt.Printf("  %-12s advertised (unknown to devgen)\n", key)
+	}
+}
+
+func supportsProtocolVersion(version string) bool {
+	for _, supported := range supportedProtocolVersions {
+		if version == supported {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+var categoryCmd = &cobra.Command{
+	Use:   "category",
+	Short: "Manage the category taxonomy",
+	Long: `Categories are a controlled vocabulary: dashboard grouping and reports
+assume a small, consistent set of names. These commands list the taxonomy
+and rename or merge categories across every server at once. Free-form
+labels belong in tags, not categories.`,
+}
+
+var categoryListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List categories and how many servers use each",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadRegistryPreferDaemon(path)
+		if err != nil {
+			return err
+		}
+		counts := categoriesInUse(reg)
+		names := make([]string, 0, len(counts))
+		for name := range counts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Printf("%-24s %s\n", "CATEGORY", "SERVERS")
+		for _, name := range names {
+			label := name
+			if label == "" {
+				label = "(uncategorized)"
+			}
+			fmt.Printf("%-24s %d\n", label, counts[name])
+		}
+		return nil
+	},
+}
+
+var categoryRenameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Rename a category across all servers",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		changed, err := recategorize(map[string]string{args[0]: args[1]})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Renamed %s to %s on %d server(s)\n", args[0], args[1], changed)
+		return nil
+	},
+}
+
+var categoryMergeCmd = &cobra.Command{
+	Use:   "merge <target> <source...>",
+	Short: "Merge one or more categories into a target category",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mapping := map[string]string{}
+		for _, source := range args[1:] {
+			mapping[source] = args[0]
+		}
+		changed, err := recategorize(mapping)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Merged into %s: %d server(s) updated\n", args[0], changed)
+		return nil
+	},
+}
+
+// categoriesInUse counts servers per category.
+func categoriesInUse(reg *MCPRegistry) map[string]int {
+	counts := map[string]int{}
+	for _, srv := range reg.Servers {
+		counts[srv.Category]++
+	}
+	return counts
+}
+
+// knownCategory reports whether a category already exists in the registry;
+// registration rejects unknown names so the taxonomy can't drift one typo
+// at a time.
+func knownCategory(reg *MCPRegistry, category string) bool {
+	if category == "" {
+		return true
+	}
+	counts := categoriesInUse(reg)
+	delete(counts, "")
+	if len(counts) == 0 {
+		return true // no taxonomy established yet
+	}
+	_, ok := counts[category]
+	return ok
+}
+
+// recategorize rewrites categories per the old-to-new mapping in one save.
+func recategorize(mapping map[string]string) (int, error) {
+	path, err := registryPath()
+	if err != nil {
+		return 0, err
+	}
+	reg, err := loadMCPRegistry(path)
+	if err != nil {
+		return 0, err
+	}
+	counts := categoriesInUse(reg)
+	for old := range mapping {
+		if counts[old] == 0 {
+			return 0, fmt.Errorf("no servers use category %q (see 'devgen category list')", old)
+		}
+	}
+	changed := 0
+	for i := range reg.Servers {
+		if target, ok := mapping[reg.Servers[i].Category]; ok {
+			reg.Servers[i].Category = target
+			changed++
+		}
+	}
+	if err := saveMCPRegistry(path, reg); err != nil {
+		return 0, err
+	}
+	return changed, nil
+}
+
+func init() {
+	categoryCmd.AddCommand(categoryListCmd, categoryRenameCmd, categoryMergeCmd)
+	rootCmd.AddCommand(categoryCmd)
+}
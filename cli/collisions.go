@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Collision policies for tools exposed by more than one server.
+const (
+	collisionPrefix   = "prefix"
+	collisionPriority = "priority"
+	collisionError    = "error"
+)
+
+// toolCollisions maps each tool name exposed by more than one server to its
+// owners, in registry order.
+func toolCollisions(reg *MCPRegistry) map[string][]string {
+	owners := map[string][]string{}
+	for _, srv := range reg.Servers {
+		for _, tool := range srv.Tools {
+			owners[tool] = append(owners[tool], srv.Name)
+		}
+	}
+	for tool, list := range owners {
+		if len(list) < 2 {
+			delete(owners, tool)
+		}
+	}
+	return owners
+}
+
+// collisionPolicyFromConfig returns the configured policy, defaulting to
+// prefix renaming, which never loses a tool.
+func collisionPolicyFromConfig(cfg proxyConfig) string {
+	if cfg.CollisionPolicy == "" {
+		return collisionPrefix
+	}
+	return cfg.CollisionPolicy
+}
+
+// priorityOwner picks the winning server for a colliding tool under the
+// priority policy: the first owner in the configured priority list, falling
+// back to registry order.
+func priorityOwner(owners, priority []string) string {
+	for _, name := range priority {
+		for _, owner := range owners {
+			if owner == name {
+				return owner
+			}
+		}
+	}
+	return owners[0]
+}
+
+// applyCollisionPolicy validates the policy against one server before the
+// proxy starts relaying, returning the set of colliding tools it owns.
+func applyCollisionPolicy(reg *MCPRegistry, srv *MCPServer, cfg proxyConfig) (map[string][]string, error) {
+	collisions := map[string][]string{}
+	for tool, owners := range toolCollisions(reg) {
+		for _, owner := range owners {
+			if owner == srv.Name {
+				collisions[tool] = owners
+			}
+		}
+	}
+	policy := collisionPolicyFromConfig(cfg)
+	switch policy {
+	case collisionPrefix, collisionPriority:
+		return collisions, nil
+	case collisionError:
+		if len(collisions) > 0 {
+			return nil, fmt.Errorf(
+				"%s exposes %d tool(s) that collide with other servers (policy: error); run 'devgen collisions'",
+				srv.Name, len(collisions))
+		}
+		return collisions, nil
+	}
+	return nil, fmt.Errorf("unknown proxy collision policy %q (want prefix, priority, or error)", policy)
+}
+
+// rewriteToolsListResponse applies the collision policy to a tools/list
+// response frame: prefix renames colliding tools to server.tool; priority
+// drops colliding tools this server doesn't win.
+func rewriteToolsListResponse(line []byte, srv string, collisions map[string][]string, cfg proxyConfig) []byte {
+	if len(collisions) == 0 {
+		return line
+	}
+	var frame map[string]interface{}
+	if err := json.Unmarshal(line, &frame); err != nil {
+		return line
+	}
+	result, ok := frame["result"].(map[string]interface{})
+	if !ok {
+		return line
+	}
+	tools, ok := result["tools"].([]interface{})
+	if !ok {
+		return line
+	}
+	policy := collisionPolicyFromConfig(cfg)
+	var kept []interface{}
+	for _, entry := range tools {
+		tool, ok := entry.(map[string]interface{})
+		if !ok {
+			kept = append(kept, entry)
+			continue
+		}
+		name, _ := tool["name"].(string)
+		owners, colliding := collisions[name]
+		if !colliding {
+			kept = append(kept, entry)
+			continue
+		}
+		switch policy {
+		case collisionPrefix:
+			tool["name"] = srv + "." + name
+			kept = append(kept, tool)
+		case collisionPriority:
+			if priorityOwner(owners, cfg.Priority) == srv {
+				kept = append(kept, tool)
+			}
+		}
+	}
+	result["tools"] = kept
+	out, err := json.Marshal(frame)
+	if err != nil {
+		return line
+	}
+	return out
+}
+
+// rewriteToolCallRequest strips this server's collision prefix from a
+// tools/call request so the child sees the tool's real name.
+func rewriteToolCallRequest(line []byte, srv string) []byte {
+	var frame map[string]interface{}
+	if err := json.Unmarshal(line, &frame); err != nil {
+		return line
+	}
+	params, ok := frame["params"].(map[string]interface{})
+	if !ok {
+		return line
+	}
+	name, _ := params["name"].(string)
+	stripped, found := strings.CutPrefix(name, srv+".")
+	if !found {
+		return line
+	}
+	params["name"] = stripped
+	out, err := json.Marshal(frame)
+	if err != nil {
+		return line
+	}
+	return out
+}
+
+var collisionsCmd = &cobra.Command{
+	Use:   "collisions",
+	Short: "Report tool name collisions and how the proxy resolves them",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		collisions := toolCollisions(reg)
+		if len(collisions) == 0 {
+			fmt.Println("No tool name collisions.")
+			return nil
+		}
+		policy := collisionPolicyFromConfig(cfg.Proxy)
+		fmt.Printf("Collision policy: %s\n\n", policy)
+		fmt.Printf("%-28s %-36s %s\n", "TOOL", "SERVERS", "RESOLUTION")
+		for _, tool := range sortedKeys(collisions) {
+			owners := collisions[tool]
+			resolution := ""
+			switch policy {
+			case collisionPrefix:
+				renamed := make([]string, len(owners))
+				for i, owner := range owners {
+					renamed[i] = owner + "." + tool
+				}
+				resolution = strings.Join(renamed, ", ")
+			case collisionPriority:
+				resolution = "served by " + priorityOwner(owners, cfg.Proxy.Priority)
+			case collisionError:
+				resolution = "proxy refuses these servers"
+			}
+			fmt.Printf("%-28s %-36s %s\n", tool, strings.Join(owners, ", "), resolution)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(collisionsCmd)
+}
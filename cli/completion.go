@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// completionCacheFileName sits beside the registry and holds the names shell
+// completion needs, so completing an argument doesn't parse the registry.
+const completionCacheFileName = "devgen_completion_cache.json"
+
+// completionCacheTTL bounds how stale completion data may get.
+const completionCacheTTL = 30 * time.Second
+
+// completionCache is the cached name data.
+type completionCache struct {
+	GeneratedAt string   `json:"generated_at"`
+	Servers     []string `json:"servers"`
+	Tools       []string `json:"tools"`
+}
+
+func completionCachePath() (string, error) {
+	path, err := registryPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), completionCacheFileName), nil
+}
+
+// loadCompletionCache returns the cache when it exists and is within TTL.
+func loadCompletionCache() *completionCache {
+	path, err := completionCachePath()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var cache completionCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil
+	}
+	generated, err := time.Parse(time.RFC3339, cache.GeneratedAt)
+	if err != nil || time.Since(generated) > completionCacheTTL {
+		return nil
+	}
+	return &cache
+}
+
+// refreshCompletionCache rebuilds the cache from the registry.
+func refreshCompletionCache() *completionCache {
+	regPath, err := registryPath()
+	if err != nil {
+		return nil
+	}
+	reg, err := loadMCPRegistry(regPath)
+	if err != nil {
+		return nil
+	}
+	cache := &completionCache{GeneratedAt: time.Now().UTC().Format(time.RFC3339)}
+	seenTools := map[string]bool{}
+	for _, srv := range reg.Servers {
+		cache.Servers = append(cache.Servers, srv.Name)
+		for _, tool := range srv.Tools {
+			if !seenTools[tool] {
+				seenTools[tool] = true
+				cache.Tools = append(cache.Tools, tool)
+			}
+		}
+	}
+	if path, err := completionCachePath(); err == nil {
+		if data, err := json.Marshal(cache); err == nil {
+			os.WriteFile(path, append(data, '\n'), 0o644)
+		}
+	}
+	return cache
+}
+
+// completionServerNames returns server names for completion, cached.
+func completionServerNames() []string {
+	if cache := loadCompletionCache(); cache != nil {
+		return cache.Servers
+	}
+	if cache := refreshCompletionCache(); cache != nil {
+		return cache.Servers
+	}
+	return nil
+}
+
+// serverNameCompletion completes a single <server> positional argument.
+func serverNameCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completionServerNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{
+		serverStatusCmd, serverToggleCmd, inspectCmd, proxyCmd, testCmd,
+		startCmd, stopCmd,
+	} {
+		cmd.ValidArgsFunction = serverNameCompletion
+	}
+}
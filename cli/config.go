@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// devgenConfig is the persistent CLI configuration, stored as JSON under
+// ~/.config/devgen/config.json.
+type devgenConfig struct {
+	// RegistryPath and RegistryURL are the defaults for --registry and the
+	// registry group's --url flag.
+	RegistryPath string `json:"registry_path,omitempty"`
+	RegistryURL  string `json:"registry_url,omitempty"`
+	// GitRegistry commits every registry mutation to a git repository in
+	// the registry's directory; see gitregistry.go.
+	GitRegistry bool         `json:"git_registry,omitempty"`
+	Keymap      keymapConfig `json:"keymap,omitempty"`
+	Theme       themeConfig  `json:"theme,omitempty"`
+	Proxy       proxyConfig  `json:"proxy,omitempty"`
+	SSH         sshConfig    `json:"ssh,omitempty"`
+	// HTTP tunes the shared outbound transport; see httpclient.go.
+	HTTP httpTuningConfig `json:"http,omitempty"`
+	// Retry is the outbound retry/backoff policy; see retry.go.
+	Retry retryConfig `json:"retry,omitempty"`
+	// Hooks wires external commands to lifecycle events; see hooks.go.
+	Hooks hooksConfig `json:"hooks,omitempty"`
+	// Events routes state changes to configured sinks; see eventbus.go.
+	Events eventsConfig `json:"events,omitempty"`
+	// Fleet links daemons on multiple hosts through NATS; see fleet.go.
+	Fleet fleetConfig `json:"fleet,omitempty"`
+	// Signing anchors launch-spec signatures to trusted keys; see signing.go.
+	Signing signingConfig `json:"signing,omitempty"`
+	// Logs configures shipping of managed server output; see logship.go.
+	Logs logShippingConfig `json:"logs,omitempty"`
+}
+
+// sshConfig tunes the devgen SSH server: a pre-auth banner, a message of
+// the day printed to interactive sessions, and limits that keep a
+// publicly exposed endpoint safe. Timeouts are Go duration strings
+// ("5m", "1h"); zero values mean unlimited.
+type sshConfig struct {
+	Banner         string `json:"banner,omitempty"`
+	MOTD           string `json:"motd,omitempty"`
+	MaxSessions    int    `json:"max_sessions,omitempty"`
+	MaxPerIP       int    `json:"max_per_ip,omitempty"`
+	IdleTimeout    string `json:"idle_timeout,omitempty"`
+	MaxSessionTime string `json:"max_session_time,omitempty"`
+}
+
+// proxyConfig controls how the proxy resolves tool name collisions between
+// servers: "prefix" renames colliding tools to server.tool, "priority"
+// keeps them only on the highest-priority owner, "error" refuses to proxy
+// a server with collisions.
+type proxyConfig struct {
+	CollisionPolicy string   `json:"collision_policy,omitempty"`
+	Priority        []string `json:"priority,omitempty"`
+}
+
+// keymapConfig selects a keybinding preset and optional per-action
+// overrides. Bindings maps action names (toggle, sort, group, ...) to the
+// keys bound to them.
+type keymapConfig struct {
+	Preset   string              `json:"preset,omitempty"`
+	Bindings map[string][]string `json:"bindings,omitempty"`
+}
+
+// configFilePath returns the devgen config file location; DEVGEN_CONFIG
+// overrides the default under the user config directory.
+func configFilePath() (string, error) {
+	if path := os.Getenv("DEVGEN_CONFIG"); path != "" {
+		return path, nil
+	}
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "devgen", "config.json"), nil
+}
+
+// loadConfig reads the config file, returning defaults when it is absent.
+func loadConfig() (*devgenConfig, error) {
+	path, err := configFilePath()
+	if err != nil {
+		return &devgenConfig{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &devgenConfig{}, nil
+		}
+		return nil, err
+	}
+	var cfg devgenConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
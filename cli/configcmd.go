@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage persistent devgen settings",
+	Long: `Reads and writes the devgen config file so settings like the registry
+path, registry URL, and theme don't need to be re-passed as flags on every
+invocation. Keys are dot paths into the config document:
+
+  devgen config set registry_url http://registry.internal:8642
+  devgen config set theme.preset dark
+  devgen config get ssh.max_sessions
+  devgen config list`,
+}
+
+// loadRawConfig reads the config file as a generic document so get/set can
+// address any key, including ones older devgen builds don't know about.
+func loadRawConfig() (map[string]interface{}, error) {
+	path, err := configFilePath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]interface{}{}, nil
+		}
+		return nil, err
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	return raw, nil
+}
+
+func saveRawConfig(raw map[string]interface{}) error {
+	path, err := configFilePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Reject documents the typed config can't represent, so a typo'd key
+	// fails at 'config set' instead of on some later command.
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var check devgenConfig
+	if err := decoder.Decode(&check); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// lookupConfigKey resolves a dot path inside the raw document.
+func lookupConfigKey(raw map[string]interface{}, key string) (interface{}, bool) {
+	parts := strings.Split(key, ".")
+	var current interface{} = raw
+	for _, part := range parts {
+		obj, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current, ok = obj[part]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// setConfigKey writes a dot path, creating intermediate objects.
+func setConfigKey(raw map[string]interface{}, key string, value interface{}) error {
+	parts := strings.Split(key, ".")
+	obj := raw
+	for _, part := range parts[:len(parts)-1] {
+		child, ok := obj[part].(map[string]interface{})
+		if !ok {
+			if _, exists := obj[part]; exists {
+				return fmt.Errorf("config key %s is not an object", part)
+			}
+			child = map[string]interface{}{}
+			obj[part] = child
+		}
+		obj = child
+	}
+	obj[parts[len(parts)-1]] = value
+	return nil
+}
+
+// flattenConfig turns the document into sorted dot-path key/value lines.
+func flattenConfig(prefix string, value interface{}, out map[string]string) {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		data, _ := json.Marshal(value)
+		out[prefix] = string(data)
+		return
+	}
+	for key, child := range obj {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		flattenConfig(path, child, out)
+	}
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print one config value",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		raw, err := loadRawConfig()
+		if err != nil {
+			return err
+		}
+		value, ok := lookupConfigKey(raw, args[0])
+		if !ok {
+			return fmt.Errorf("config key not set: %s", args[0])
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set one config value",
+	Long: `Sets a config key. Values parse as JSON when they look like it (numbers,
+booleans, arrays), and as plain strings otherwise.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		raw, err := loadRawConfig()
+		if err != nil {
+			return err
+		}
+		var value interface{}
+		if err := json.Unmarshal([]byte(args[1]), &value); err != nil {
+			value = args[1]
+		}
+		if err := setConfigKey(raw, args[0], value); err != nil {
+			return err
+		}
+		if err := saveRawConfig(raw); err != nil {
+			return err
+		}
+		fmt.Printf("%s = %s\n", args[0], args[1])
+		return nil
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all configured settings",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		raw, err := loadRawConfig()
+		if err != nil {
+			return err
+		}
+		if len(raw) == 0 {
+			path, _ := configFilePath()
+			fmt.Printf("no settings configured (config file: %s)\n", path)
+			return nil
+		}
+		flat := map[string]string{}
+		flattenConfig("", raw, flat)
+		for _, key := range sortedKeys(flat) {
+			fmt.Printf("%s = %s\n", key, flat[key])
+		}
+		return nil
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configGetCmd, configSetCmd, configListCmd)
+	rootCmd.AddCommand(configCmd)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/term"
+)
+
+// yesFlag skips confirmation prompts for destructive actions.
+var yesFlag bool
+
+// confirmAction prompts before a destructive action. --yes (and --dry-run,
+// which changes nothing anyway) skip the prompt; without a terminal the
+// action is refused rather than guessed at.
+func confirmAction(prompt string) error {
+	if yesFlag || dryRunFlag {
+		return nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("refusing to %s without confirmation: pass --yes in non-interactive use", prompt)
+	}
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true)
+	fmt.Printf("%s %s ", style.Render("?"), strings.ToUpper(prompt[:1])+prompt[1:]+"? [y/N]")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("reading confirmation: %w", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return nil
+	default:
+		return fmt.Errorf("aborted")
+	}
+}
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&yesFlag, "yes", false,
+		"assume yes for confirmation prompts")
+}
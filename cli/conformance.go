@@ -0,0 +1,219 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// conformanceResult is one check in the conformance suite.
+type conformanceResult struct {
+	Name     string
+	Duration time.Duration
+	Err      error
+}
+
+var (
+	testJUnitFlag  string
+	testBudgetFlag time.Duration
+)
+
+var testCmd = &cobra.Command{
+	Use:   "test <server>",
+	Short: "Run the MCP conformance suite against a server",
+	Long: `Runs a conformance suite against the named server: initialize
+handshake, tools/list schema validity, presence of the tools recorded in the
+registry, error handling for invalid arguments, and per-call timing budgets.
+Results can be written as JUnit XML for CI with --junit.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		srv := reg.findServer(args[0])
+		if srv == nil {
+			return serverNotFoundError(args[0])
+		}
+
+		results := runConformanceSuite(srv)
+
+		failures := 0
+		for _, res := range results {
+			status := "PASS"
+			if res.Err != nil {
+				status = "FAIL"
+				failures++
+			}
+			fmt.Printf("%-4s %-40s %8.1fms", status, res.Name, float64(res.Duration.Microseconds())/1000)
+			if res.Err != nil {
+				fmt.Printf("  %v", res.Err)
+			}
+			fmt.Println()
+		}
+		fmt.Printf("\n%d checks, %d failures\n", len(results), failures)
+
+		if testJUnitFlag != "" {
+			if err := writeJUnitReport(testJUnitFlag, srv.Name, results); err != nil {
+				return fmt.Errorf("writing junit report: %w", err)
+			}
+			fmt.Printf("JUnit report written to %s\n", testJUnitFlag)
+		}
+		if failures > 0 {
+			return fmt.Errorf("%d conformance checks failed", failures)
+		}
+		return nil
+	},
+}
+
+func init() {
+	testCmd.Flags().StringVar(&testJUnitFlag, "junit", "", "write a JUnit XML report to this path")
+	testCmd.Flags().DurationVar(&testBudgetFlag, "budget", 2*time.Second, "per-call timing budget")
+	rootCmd.AddCommand(testCmd)
+}
+
+// runConformanceSuite executes all checks in order, reusing one client
+// connection after the handshake check succeeds.
+func runConformanceSuite(srv *MCPServer) []conformanceResult {
+	var results []conformanceResult
+	timed := func(name string, fn func() error) bool {
+		started := time.Now()
+		err := fn()
+		duration := time.Since(started)
+		if err == nil && duration > testBudgetFlag {
+			err = fmt.Errorf("exceeded timing budget of %s", testBudgetFlag)
+		}
+		results = append(results, conformanceResult{Name: name, Duration: duration, Err: err})
+		return err == nil
+	}
+
+	var client *mcpClient
+	if !timed("initialize handshake", func() error {
+		var err error
+		client, err = dialStdioServer(srv)
+		return err
+	}) {
+		return results
+	}
+	defer client.Close()
+
+	var tools []toolInfo
+	timed("tools/list returns valid schemas", func() error {
+		var err error
+		tools, err = client.listTools()
+		if err != nil {
+			return err
+		}
+		for _, tool := range tools {
+			if tool.Name == "" {
+				return fmt.Errorf("tool with empty name in tools/list")
+			}
+			if len(tool.InputSchema) > 0 {
+				var schema map[string]interface{}
+				if err := json.Unmarshal(tool.InputSchema, &schema); err != nil {
+					return fmt.Errorf("tool %s: inputSchema is not an object: %w", tool.Name, err)
+				}
+			}
+		}
+		return nil
+	})
+
+	timed("registry tools are present", func() error {
+		available := map[string]bool{}
+		for _, tool := range tools {
+			available[tool.Name] = true
+		}
+		var missing []string
+		for _, name := range srv.Tools {
+			if !available[name] {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("registry lists tools the server does not expose: %v", missing)
+		}
+		return nil
+	})
+
+	timed("invalid arguments are rejected", func() error {
+		if len(tools) == 0 {
+			return nil // nothing to probe
+		}
+		_, err := client.call("tools/call", map[string]interface{}{
+			"name":      tools[0].Name,
+			"arguments": map[string]interface{}{"__devgen_invalid_arg__": true},
+		}, testBudgetFlag)
+		// Either a JSON-RPC error or an isError result is acceptable; a
+		// transport failure is not.
+		if err != nil {
+			if _, ok := err.(*rpcError); ok {
+				return nil
+			}
+			return fmt.Errorf("transport failure on bad arguments: %w", err)
+		}
+		return nil
+	})
+
+	timed("unknown tool returns an error", func() error {
+		_, err := client.call("tools/call", map[string]interface{}{
+			"name":      "__devgen_no_such_tool__",
+			"arguments": map[string]interface{}{},
+		}, testBudgetFlag)
+		if err == nil {
+			return fmt.Errorf("server accepted a call to a nonexistent tool")
+		}
+		if _, ok := err.(*rpcError); ok {
+			return nil
+		}
+		return fmt.Errorf("transport failure on unknown tool: %w", err)
+	})
+
+	return results
+}
+
+// JUnit XML schema (the subset CI systems consume).
+type junitTestSuite struct {
+	XMLName  xml.Name        `xml:"testsuite"`
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     float64         `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name    string        `xml:"name,attr"`
+	Time    float64       `xml:"time,attr"`
+	Failure *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+}
+
+func writeJUnitReport(path, serverName string, results []conformanceResult) error {
+	suite := junitTestSuite{Name: "devgen-conformance-" + serverName}
+	for _, res := range results {
+		testCase := junitTestCase{Name: res.Name, Time: res.Duration.Seconds()}
+		if res.Err != nil {
+			suite.Failures++
+			testCase.Failure = &junitFailure{Message: res.Err.Error()}
+		}
+		suite.Tests++
+		suite.Time += res.Duration.Seconds()
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	data, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append([]byte(xml.Header), append(data, '\n')...), 0o644)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"time"
+)
+
+// daemonSocketFileName is the unix socket the daemon exposes beside the
+// registry for the CLI control plane.
+const daemonSocketFileName = "devgen_daemon.sock"
+
+func daemonSocketPath() (string, error) {
+	path, err := registryPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), daemonSocketFileName), nil
+}
+
+// controlClient speaks HTTP over the daemon's unix socket.
+func controlClient(socket string) *http.Client {
+	return &http.Client{
+		Timeout: 2 * time.Second,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socket)
+			},
+		},
+	}
+}
+
+// loadRegistryViaControlPlane fetches the registry from a running daemon
+// through its unix socket, so reads observe the daemon's serialized view
+// rather than racing its writes.
+func loadRegistryViaControlPlane() (*MCPRegistry, error) {
+	socket, err := daemonSocketPath()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := controlClient(socket).Get("http://devgen/registry")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("control plane: %s", resp.Status)
+	}
+	var reg MCPRegistry
+	if err := json.NewDecoder(resp.Body).Decode(&reg); err != nil {
+		return nil, fmt.Errorf("control plane: %w", err)
+	}
+	return &reg, nil
+}
+
+// loadRegistryPreferDaemon reads registry state through the daemon when one
+// is running, falling back to the file when it is not.
+func loadRegistryPreferDaemon(path string) (*MCPRegistry, error) {
+	if reg, err := loadRegistryViaControlPlane(); err == nil {
+		debugf("control plane: registry read via daemon socket")
+		return reg, nil
+	}
+	return loadMCPRegistry(path)
+}
@@ -0,0 +1,350 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// daemonPIDFileName sits beside the registry so every devgen invocation in
+// the repo finds the same daemon.
+const daemonPIDFileName = "devgen_daemon.pid"
+
+// daemonLogFileName receives the daemon's structured JSONL log.
+const daemonLogFileName = "devgen-daemon.log"
+
+// daemonSuperviseInterval is how often the daemon sweeps managed processes
+// and health.
+const daemonSuperviseInterval = 15 * time.Second
+
+var daemonAddrFlag string
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run supervisor, health checker, and API server as one process",
+	Long: `Runs the process supervisor, periodic health checks, and the HTTP
+registration endpoint as a single long-lived process. A PID file is kept
+beside the registry and structured JSONL logs go to logs/devgen-daemon.log
+in the machina root. Control it with 'devgen daemon status' and
+'devgen daemon stop'.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDaemon()
+	},
+}
+
+var daemonStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Report whether the devgen daemon is running",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pid, err := daemonPID()
+		if err != nil {
+			return err
+		}
+		if pid == 0 || !processAlive(pid) {
+			fmt.Println("daemon: not running")
+			return nil
+		}
+		fmt.Printf("daemon: running (pid %d)\n", pid)
+		return nil
+	},
+}
+
+var daemonStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the devgen daemon",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pid, err := daemonPID()
+		if err != nil {
+			return err
+		}
+		if pid == 0 || !processAlive(pid) {
+			return fmt.Errorf("daemon is not running")
+		}
+		if err := terminateProcess(pid); err != nil {
+			return fmt.Errorf("stopping daemon (pid %d): %w", pid, err)
+		}
+		fmt.Printf("Stopped daemon (pid %d)\n", pid)
+		return nil
+	},
+}
+
+func daemonPIDPath() (string, error) {
+	path, err := registryPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), daemonPIDFileName), nil
+}
+
+// daemonPID reads the PID file, returning 0 when no daemon was started.
+func daemonPID() (int, error) {
+	path, err := daemonPIDPath()
+	if err != nil {
+		return 0, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, fmt.Errorf("corrupt PID file %s: %w", path, err)
+	}
+	return pid, nil
+}
+
+// daemonLogger writes the daemon's structured log lines.
+type daemonLogger struct {
+	file *os.File
+}
+
+func newDaemonLogger(root string) (*daemonLogger, error) {
+	logDir := filepath.Join(root, "logs")
+	if err := os.MkdirAll(logDir, 0o755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(filepath.Join(logDir, daemonLogFileName),
+		os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &daemonLogger{file: f}, nil
+}
+
+func (l *daemonLogger) log(eventName, server string, fields map[string]string) {
+	data, err := json.Marshal(event{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Event:     eventName,
+		Server:    server,
+		Fields:    fields,
+	})
+	if err != nil {
+		return
+	}
+	l.file.Write(append(data, '\n'))
+}
+
+func runDaemon() error {
+	defer recoverWithDiagnostics("daemon")
+	regPath, err := registryPath()
+	if err != nil {
+		return err
+	}
+	root, err := findMachinaRoot()
+	if err != nil {
+		return err
+	}
+	if pid, err := daemonPID(); err != nil {
+		return err
+	} else if pid != 0 && processAlive(pid) {
+		return fmt.Errorf("daemon already running (pid %d)", pid)
+	}
+
+	pidPath, err := daemonPIDPath()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(pidPath, []byte(fmt.Sprintf("%d\n", os.Getpid())), 0o644); err != nil {
+		return err
+	}
+	defer os.Remove(pidPath)
+
+	logger, err := newDaemonLogger(root)
+	if err != nil {
+		return err
+	}
+	defer logger.file.Close()
+	logger.log("daemon_started", "", map[string]string{"pid": fmt.Sprintf("%d", os.Getpid()), "addr": daemonAddrFlag})
+
+	// Fleet bus: publish this host's server states after every sweep so
+	// 'devgen fleet' on any host sees the whole deployment.
+	var fleet *fleetPublisher
+	if cfg, err := loadConfig(); err == nil {
+		fleet, err = newFleetPublisher(cfg.Fleet)
+		if err != nil {
+			logger.log("fleet_error", "", map[string]string{"error": err.Error()})
+		} else if fleet != nil {
+			defer fleet.close()
+		}
+	}
+
+	// API server: the same endpoint 'devgen serve' exposes.
+	receiver := &webhookReceiver{registryPath: regPath}
+	go receiver.sweepStale()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/register", receiver.handleRegister)
+	mux.HandleFunc("/heartbeat", receiver.handleHeartbeat)
+	mux.HandleFunc("/deregister", receiver.handleDeregister)
+	mux.HandleFunc("/servers", receiver.handleServers)
+	mux.HandleFunc("/registry", receiver.handleRegistry)
+	server := &http.Server{Addr: daemonAddrFlag, Handler: mux}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.log("daemon_api_error", "", map[string]string{"error": err.Error()})
+		}
+	}()
+
+	// Unix socket control plane: CLI commands read state through the daemon
+	// when one is running instead of re-parsing mcp_status.json.
+	socketPath, err := daemonSocketPath()
+	if err != nil {
+		return err
+	}
+	os.Remove(socketPath)
+	socket, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("control plane socket: %w", err)
+	}
+	defer os.Remove(socketPath)
+	socketServer := &http.Server{Handler: mux}
+	go func() {
+		if err := socketServer.Serve(socket); err != nil && err != http.ErrServerClosed {
+			logger.log("daemon_socket_error", "", map[string]string{"error": err.Error()})
+		}
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	ticker := time.NewTicker(daemonSuperviseInterval)
+	defer ticker.Stop()
+
+	fmt.Printf("devgen daemon running (pid %d, api %s)\n", os.Getpid(), daemonAddrFlag)
+	for {
+		select {
+		case <-ticker.C:
+			superviseSweep(regPath, logger)
+			if fleet != nil {
+				if err := fleet.publish(regPath); err != nil {
+					logger.log("fleet_error", "", map[string]string{"error": err.Error()})
+				}
+			}
+		case sig := <-stop:
+			logger.log("daemon_stopped", "", map[string]string{"signal": sig.String()})
+			server.Close()
+			socketServer.Close()
+			return nil
+		}
+	}
+}
+
+// superviseSweep applies restart policies and resource limits to managed
+// processes and refreshes health probe results in the registry.
+func superviseSweep(regPath string, logger *daemonLogger) {
+	reg, err := loadMCPRegistry(regPath)
+	if err != nil {
+		logger.log("sweep_error", "", map[string]string{"error": err.Error()})
+		return
+	}
+	state, err := loadManagedState()
+	if err != nil {
+		logger.log("sweep_error", "", map[string]string{"error": err.Error()})
+		return
+	}
+
+	stateChanged := false
+	for name, proc := range state.Servers {
+		srv := reg.findServer(name)
+		if srv == nil {
+			continue
+		}
+		if !processAlive(proc.PID) {
+			if !shouldAutoRestart(srv) || !backoffElapsed(srv, proc) {
+				continue
+			}
+			if err := restartManagedServer(srv, state); err != nil {
+				logger.log("restart_failed", name, map[string]string{"error": err.Error()})
+			} else {
+				logger.log("server_restarted", name, map[string]string{"reason": "exited"})
+			}
+			stateChanged = true
+			continue
+		}
+		sample, err := sampleProcess(proc.PID)
+		if err != nil {
+			continue
+		}
+		if overResourceLimits(srv, sample) {
+			if err := restartManagedServer(srv, state); err != nil {
+				logger.log("restart_failed", name, map[string]string{"error": err.Error()})
+			} else {
+				logger.log("server_restarted", name, map[string]string{"reason": "over resource limits"})
+			}
+			stateChanged = true
+		}
+	}
+	if stateChanged {
+		if err := saveManagedState(state); err != nil {
+			logger.log("sweep_error", "", map[string]string{"error": err.Error()})
+		}
+	}
+
+	// Health checks: record probe results on the registry entries. Raw
+	// probes feed the flap damping state machine so a single bad probe
+	// doesn't raise an alert; only damped transitions are logged.
+	results := probeAll(reg.Servers)
+	health, err := loadHealthState()
+	if err != nil {
+		logger.log("sweep_error", "", map[string]string{"error": err.Error()})
+		return
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	changed := false
+	for i := range reg.Servers {
+		srv := &reg.Servers[i]
+		probe, ok := results[srv.Name]
+		if !ok {
+			continue
+		}
+		srv.LastHealthCheck = now
+		changed = true
+		rec := health.Servers[srv.Name]
+		transitioned, err := applyDamping(srv, &rec, probe)
+		if err != nil {
+			logger.log("sweep_error", srv.Name, map[string]string{"error": err.Error()})
+			continue
+		}
+		health.Servers[srv.Name] = rec
+		if transitioned {
+			appendHealthTransition(srv.Name, rec.State, probe.CheckedAt)
+			if rec.State == "down" {
+				fireHealthFail(srv.Name, probe.Detail)
+			}
+		}
+		if transitioned && !inMaintenance(srv) {
+			fields := map[string]string{"detail": probe.Detail}
+			if owner := ownerLine(srv); owner != "" {
+				fields["owner"] = owner
+			}
+			logger.log("health_"+rec.State, srv.Name, fields)
+		}
+	}
+	if err := saveHealthState(health); err != nil {
+		logger.log("sweep_error", "", map[string]string{"error": err.Error()})
+	}
+	if changed {
+		if err := saveMCPRegistry(regPath, reg); err != nil {
+			logger.log("sweep_error", "", map[string]string{"error": err.Error()})
+		}
+	}
+
+	shipLogsSweep(reg, logger)
+}
+
+func init() {
+	daemonCmd.Flags().StringVar(&daemonAddrFlag, "addr", ":8642", "API listen address")
+	daemonCmd.AddCommand(daemonStatusCmd, daemonStopCmd)
+	rootCmd.AddCommand(daemonCmd)
+}
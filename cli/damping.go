@@ -0,0 +1,239 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// healthStateFileName holds the damped health state machine per server,
+// stored next to the registry like managed_servers.json.
+const healthStateFileName = "health_state.json"
+
+// Damping defaults, used when a server declares no health_thresholds.
+const (
+	defaultFailuresToDown = 3
+	defaultSuccessesToUp  = 2
+)
+
+// healthRecord is one server's damped state: the effective up/down verdict
+// plus the counters driving the next transition.
+type healthRecord struct {
+	State                string `json:"state"` // "up" or "down"
+	ConsecutiveFailures  int    `json:"consecutive_failures,omitempty"`
+	ConsecutiveSuccesses int    `json:"consecutive_successes,omitempty"`
+	LastTransition       string `json:"last_transition,omitempty"`
+	LastProbe            string `json:"last_probe,omitempty"`
+	LastDetail           string `json:"last_detail,omitempty"`
+}
+
+type healthStateFile struct {
+	Servers map[string]healthRecord `json:"servers"`
+}
+
+func healthStatePath() (string, error) {
+	path, err := registryPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), healthStateFileName), nil
+}
+
+func loadHealthState() (*healthStateFile, error) {
+	path, err := healthStatePath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &healthStateFile{Servers: map[string]healthRecord{}}, nil
+		}
+		return nil, err
+	}
+	var state healthStateFile
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if state.Servers == nil {
+		state.Servers = map[string]healthRecord{}
+	}
+	return &state, nil
+}
+
+func saveHealthState(state *healthStateFile) error {
+	path, err := healthStatePath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// effectiveThresholds resolves a server's damping parameters, applying
+// defaults for unset fields.
+func effectiveThresholds(srv *MCPServer) (down, up int, cooldown time.Duration, err error) {
+	down, up = defaultFailuresToDown, defaultSuccessesToUp
+	if srv.Health == nil {
+		return down, up, 0, nil
+	}
+	if srv.Health.FailuresToDown > 0 {
+		down = srv.Health.FailuresToDown
+	}
+	if srv.Health.SuccessesToUp > 0 {
+		up = srv.Health.SuccessesToUp
+	}
+	if srv.Health.Cooldown != "" {
+		cooldown, err = time.ParseDuration(srv.Health.Cooldown)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("%s: bad health cooldown %q: %w", srv.Name, srv.Health.Cooldown, err)
+		}
+	}
+	return down, up, cooldown, nil
+}
+
+// applyDamping feeds one probe result into a server's state machine and
+// reports whether the damped state transitioned. The first observation
+// seeds the state from the raw probe without damping; healthUnknown probes
+// leave the counters untouched since they say nothing about the server.
+func applyDamping(srv *MCPServer, rec *healthRecord, probe probeResult) (bool, error) {
+	down, up, cooldown, err := effectiveThresholds(srv)
+	if err != nil {
+		return false, err
+	}
+	rec.LastProbe = probe.State.String()
+	rec.LastDetail = probe.Detail
+	if probe.State == healthUnknown {
+		return false, nil
+	}
+	healthy := probe.State == healthHealthy
+
+	if rec.State == "" {
+		// Seeding is not a flap: LastTransition stays empty so the
+		// cooldown doesn't delay the first real transition.
+		rec.State = "down"
+		if healthy {
+			rec.State = "up"
+		}
+		return false, nil
+	}
+
+	if healthy {
+		rec.ConsecutiveSuccesses++
+		rec.ConsecutiveFailures = 0
+	} else {
+		rec.ConsecutiveFailures++
+		rec.ConsecutiveSuccesses = 0
+	}
+
+	target := rec.State
+	if rec.State == "up" && rec.ConsecutiveFailures >= down {
+		target = "down"
+	}
+	if rec.State == "down" && rec.ConsecutiveSuccesses >= up {
+		target = "up"
+	}
+	if target == rec.State {
+		return false, nil
+	}
+	if cooldown > 0 {
+		if last, err := time.Parse(time.RFC3339, rec.LastTransition); err == nil &&
+			probe.CheckedAt.Sub(last) < cooldown {
+			return false, nil
+		}
+	}
+	rec.State = target
+	rec.ConsecutiveFailures = 0
+	rec.ConsecutiveSuccesses = 0
+	rec.LastTransition = probe.CheckedAt.UTC().Format(time.RFC3339)
+	return true, nil
+}
+
+var healthExplainCmd = &cobra.Command{
+	Use:   "explain <server>",
+	Short: "Show a server's health state machine and counters",
+	Long: `Probes the server once, feeds the result through flap damping, and
+prints the effective thresholds, current counters, and what it would take
+to transition the damped state.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		srv := reg.findServer(args[0])
+		if srv == nil {
+			return serverNotFoundError(args[0])
+		}
+		down, up, cooldown, err := effectiveThresholds(srv)
+		if err != nil {
+			return err
+		}
+		state, err := loadHealthState()
+		if err != nil {
+			return err
+		}
+		rec := state.Servers[srv.Name]
+		probe := probeServer(srv)
+		transitioned, err := applyDamping(srv, &rec, probe)
+		if err != nil {
+			return err
+		}
+		state.Servers[srv.Name] = rec
+		if err := saveHealthState(state); err != nil {
+			return err
+		}
+		if transitioned {
+			appendHealthTransition(srv.Name, rec.State, probe.CheckedAt)
+			if rec.State == "down" {
+				fireHealthFail(srv.Name, probe.Detail)
+			}
+		}
+
+		fmt.Printf("Server:       %s\n", srv.Name)
+		fmt.Printf("Damped state: %s", rec.State)
+		if transitioned {
+			fmt.Printf(" (just transitioned)")
+		}
+		fmt.Println()
+		fmt.Printf("Last probe:   %s", rec.LastProbe)
+		if rec.LastDetail != "" {
+			fmt.Printf(" (%s)", rec.LastDetail)
+		}
+		fmt.Println()
+		fmt.Printf("Thresholds:   %d failure(s) to go down, %d success(es) to go up", down, up)
+		if cooldown > 0 {
+			fmt.Printf(", %s cooldown between transitions", cooldown)
+		}
+		fmt.Println()
+		fmt.Printf("Counters:     %d consecutive failure(s), %d consecutive success(es)\n",
+			rec.ConsecutiveFailures, rec.ConsecutiveSuccesses)
+		if rec.LastTransition != "" {
+			fmt.Printf("Transitioned: %s\n", rec.LastTransition)
+		}
+		switch rec.State {
+		case "up":
+			fmt.Printf("Next:         %d more consecutive failure(s) would mark %s down\n",
+				down-rec.ConsecutiveFailures, srv.Name)
+		case "down":
+			fmt.Printf("Next:         %d more consecutive success(es) would mark %s up\n",
+				up-rec.ConsecutiveSuccesses, srv.Name)
+		}
+		return nil
+	},
+}
+
+func init() {
+	healthCmd.AddCommand(healthExplainCmd)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func feedProbe(t *testing.T, srv *MCPServer, rec *healthRecord, state healthState, at time.Time) bool {
+	t.Helper()
+	transitioned, err := applyDamping(srv, rec, probeResult{State: state, CheckedAt: at})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return transitioned
+}
+
+func TestApplyDampingSuppressesBlips(t *testing.T) {
+	srv := &MCPServer{Name: "s"}
+	rec := healthRecord{}
+	now := time.Now()
+
+	feedProbe(t, srv, &rec, healthHealthy, now) // seeds "up"
+	if rec.State != "up" {
+		t.Fatalf("seed state = %s, want up", rec.State)
+	}
+
+	// Two failures stay below the default threshold of three.
+	feedProbe(t, srv, &rec, healthUnreachable, now)
+	if feedProbe(t, srv, &rec, healthUnreachable, now) {
+		t.Error("two failures should not transition with default thresholds")
+	}
+	// A success in between resets the failure streak.
+	feedProbe(t, srv, &rec, healthHealthy, now)
+	if rec.ConsecutiveFailures != 0 {
+		t.Errorf("failure counter = %d after success, want 0", rec.ConsecutiveFailures)
+	}
+
+	for i := 0; i < 2; i++ {
+		feedProbe(t, srv, &rec, healthUnreachable, now)
+	}
+	if !feedProbe(t, srv, &rec, healthUnreachable, now) || rec.State != "down" {
+		t.Errorf("third consecutive failure should mark down, state = %s", rec.State)
+	}
+
+	// Default two successes bring it back up.
+	feedProbe(t, srv, &rec, healthHealthy, now)
+	if !feedProbe(t, srv, &rec, healthHealthy, now) || rec.State != "up" {
+		t.Errorf("two successes should mark up, state = %s", rec.State)
+	}
+}
+
+func TestApplyDampingCooldown(t *testing.T) {
+	srv := &MCPServer{Name: "s", Health: &HealthThresholds{
+		FailuresToDown: 1,
+		SuccessesToUp:  1,
+		Cooldown:       "1m",
+	}}
+	rec := healthRecord{}
+	now := time.Now()
+
+	feedProbe(t, srv, &rec, healthHealthy, now)
+	if !feedProbe(t, srv, &rec, healthUnreachable, now.Add(time.Second)) {
+		t.Fatal("single failure should transition with failures_to_down=1")
+	}
+	// Recovery inside the cooldown window is held back.
+	if feedProbe(t, srv, &rec, healthHealthy, now.Add(2*time.Second)) {
+		t.Error("transition inside the cooldown window should be suppressed")
+	}
+	if !feedProbe(t, srv, &rec, healthHealthy, now.Add(2*time.Minute)) || rec.State != "up" {
+		t.Errorf("transition after the cooldown should apply, state = %s", rec.State)
+	}
+}
+
+func TestApplyDampingBadCooldown(t *testing.T) {
+	srv := &MCPServer{Name: "s", Health: &HealthThresholds{Cooldown: "soon"}}
+	rec := healthRecord{}
+	if _, err := applyDamping(srv, &rec, probeResult{State: healthHealthy, CheckedAt: time.Now()}); err == nil {
+		t.Error("unparseable cooldown should be an error")
+	}
+}
@@ -0,0 +1,841 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-runewidth"
+	"github.com/muesli/reflow/wordwrap"
+	"github.com/spf13/cobra"
+)
+
+// sortMode selects the dashboard sort order.
+type sortMode int
+
+const (
+	sortByName sortMode = iota
+	sortByStatus
+	sortByCategory
+	sortByToolCount
+	sortByLastCheck
+	sortModeCount
+)
+
+func (m sortMode) String() string {
+	switch m {
+	case sortByName:
+		return "name"
+	case sortByStatus:
+		return "status"
+	case sortByCategory:
+		return "category"
+	case sortByToolCount:
+		return "tools"
+	case sortByLastCheck:
+		return "last check"
+	}
+	return "unknown"
+}
+
+// dashboardItem is one selectable row: either a category header (grouped
+// mode) or a server card.
+type dashboardItem struct {
+	header   string // non-empty for category headers
+	serverIx int    // index into model.servers for server rows
+}
+
+type dashboardModel struct {
+	registryPath string
+	servers      []MCPServer
+	items        []dashboardItem
+	cursor       int
+	sort         sortMode
+	grouped      bool
+	collapsed    map[string]bool
+	width        int
+	height       int
+	keys         dashboardKeyMap
+	help         help.Model
+	showHelp     bool
+	styles       dashboardStyles
+	status       statusBar
+	health       map[string]probeResult
+	resources    map[string]procSample
+	selector     labelSelector
+	filtering    bool   // true while the / search prompt captures input
+	filter       string // active search query
+	warnings     int    // registry conflicts found at load time
+	playground   *playgroundModel
+	workers      *dashboardWorkers
+	orderScratch []int // reused by rebuildItems to avoid per-key allocation
+	fleet        fleetConfig
+	fleetHosts   map[string]fleetSnapshot // latest snapshot per host when fleet mode is on
+	err          error
+}
+
+// resourceResultsMsg delivers resource samples for managed processes.
+type resourceResultsMsg map[string]procSample
+
+// healthResultsMsg delivers a completed background probe sweep.
+type healthResultsMsg map[string]probeResult
+
+// fleetResultsMsg delivers the latest fleet snapshots per host.
+type fleetResultsMsg map[string]fleetSnapshot
+
+// healthTickMsg schedules the next probe sweep.
+type healthTickMsg struct{}
+
+// healthProbeInterval is how often the dashboard re-probes servers.
+const healthProbeInterval = 10 * time.Second
+
+// statusBar is the transient feedback line shown under the table.
+type statusBar struct {
+	text string
+	kind statusKind
+	seq  int // guards against stale clear timers
+}
+
+type statusKind int
+
+const (
+	statusInfo statusKind = iota
+	statusSuccess
+	statusError
+)
+
+type registryLoadedMsg struct {
+	servers  []MCPServer
+	warnings int
+	err      error
+}
+
+// serverToggledMsg reports the outcome of an async toggle.
+type serverToggledMsg struct {
+	name    string
+	enabled bool
+	err     error
+}
+
+// clearStatusMsg expires the status bar after a delay.
+type clearStatusMsg struct{ seq int }
+
+var dashboardCmd = &cobra.Command{
+	Use:   "dashboard",
+	Short: "Interactive MCP server dashboard",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		defer recoverWithDiagnostics("dashboard")
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		var selector labelSelector
+		if dashboardSelectorFlag != "" {
+			selector, err = parseSelector(dashboardSelectorFlag)
+			if err != nil {
+				return err
+			}
+		}
+		model := dashboardModel{
+			registryPath: path,
+			collapsed:    map[string]bool{},
+			keys:         keymapFromConfig(cfg.Keymap),
+			help:         help.New(),
+			styles:       newDashboardStyles(resolveTheme(cfg.Theme)),
+			selector:     selector,
+			fleet:        cfg.Fleet,
+		}
+		if dashboardRenderOnceFlag {
+			return renderDashboardOnce(model, dashboardWidthFlag, dashboardHeightFlag)
+		}
+		model.workers = startDashboardWorkers()
+		defer model.workers.stop()
+		_, err = tea.NewProgram(model, tea.WithAltScreen()).Run()
+		return err
+	},
+}
+
+// renderDashboardOnce prints a single dashboard frame and exits: the same
+// View the TUI draws, fed by one synchronous registry load and probe
+// sweep. Styling follows the terminal as usual, so piping the output
+// yields plain text for docs and visual regression checks.
+func renderDashboardOnce(model dashboardModel, width, height int) error {
+	reg, err := cachedRegistry(model.registryPath)
+	if err != nil {
+		return err
+	}
+	model.width = width
+	model.height = height
+	model.help.Width = width
+	model.servers = filterServersBySelector(reg.Servers, model.selector)
+	model.warnings = len(registryWarnings(reg))
+	model.rebuildItems()
+	model.health = probeAll(model.servers)
+	fmt.Print(model.View())
+	return nil
+}
+
+var (
+	dashboardSelectorFlag   string
+	dashboardRenderOnceFlag bool
+	dashboardWidthFlag      int
+	dashboardHeightFlag     int
+)
+
+func init() {
+	dashboardCmd.Flags().StringVarP(&dashboardSelectorFlag, "selector", "l", "",
+		"show only servers matching a label selector, e.g. env=prod,team=ml")
+	dashboardCmd.Flags().BoolVar(&dashboardRenderOnceFlag, "render-once", false,
+		"render a single frame to stdout and exit")
+	dashboardCmd.Flags().IntVar(&dashboardWidthFlag, "width", defaultDashboardWidth,
+		"frame width for --render-once")
+	dashboardCmd.Flags().IntVar(&dashboardHeightFlag, "height", 40,
+		"frame height for --render-once")
+	rootCmd.AddCommand(dashboardCmd)
+}
+
+func (m dashboardModel) Init() tea.Cmd {
+	return tea.Batch(
+		m.background(loadRegistryCmd(m.registryPath, m.selector)),
+		m.pump(),
+		func() tea.Msg { return healthTickMsg{} },
+	)
+}
+
+// background runs work through the supervised pool when it exists, and
+// falls back to a plain command for --render-once and tests.
+func (m dashboardModel) background(work tea.Cmd) tea.Cmd {
+	if m.workers == nil {
+		return work
+	}
+	m.workers.submit(workerRequest(work))
+	return nil
+}
+
+// pump keeps exactly one awaitEvent command outstanding while the worker
+// pool is alive; every message delivered through it returns a new pump.
+func (m dashboardModel) pump() tea.Cmd {
+	if m.workers == nil {
+		return nil
+	}
+	return m.workers.awaitEvent()
+}
+
+// probeHealthCmd runs one concurrent probe sweep off the UI goroutine.
+func probeHealthCmd(servers []MCPServer) tea.Cmd {
+	return func() tea.Msg {
+		return healthResultsMsg(probeAll(servers))
+	}
+}
+
+func nextHealthTick() tea.Cmd {
+	return tea.Tick(healthProbeInterval, func(time.Time) tea.Msg { return healthTickMsg{} })
+}
+
+// collectFleetCmd listens briefly on the fleet bus for host snapshots.
+// Collection failures keep the previous view rather than surfacing an
+// error every probe interval.
+func collectFleetCmd(cfg fleetConfig) tea.Cmd {
+	return func() tea.Msg {
+		hosts, err := collectFleetSnapshots(cfg, 2*time.Second)
+		if err != nil {
+			debugf("dashboard: fleet collect: %v", err)
+			return fleetResultsMsg(nil)
+		}
+		return fleetResultsMsg(hosts)
+	}
+}
+
+// sampleResourcesCmd measures resource usage of managed processes in the
+// background.
+func sampleResourcesCmd() tea.Cmd {
+	return func() tea.Msg {
+		state, err := loadManagedState()
+		if err != nil {
+			return resourceResultsMsg(nil)
+		}
+		results := map[string]procSample{}
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for name, proc := range state.Servers {
+			if !processAlive(proc.PID) {
+				continue
+			}
+			wg.Add(1)
+			go func(name string, pid int) {
+				defer wg.Done()
+				if sample, err := sampleProcess(pid); err == nil {
+					mu.Lock()
+					results[name] = sample
+					mu.Unlock()
+				}
+			}(name, proc.PID)
+		}
+		wg.Wait()
+		return resourceResultsMsg(results)
+	}
+}
+
+func loadRegistryCmd(path string, selector labelSelector) tea.Cmd {
+	return func() tea.Msg {
+		reg, err := cachedRegistry(path)
+		if err != nil {
+			return registryLoadedMsg{err: err}
+		}
+		return registryLoadedMsg{
+			servers:  filterServersBySelector(reg.Servers, selector),
+			warnings: len(registryWarnings(reg)),
+		}
+	}
+}
+
+func (m dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// The playground overlay captures keys and its own messages while
+	// open; window resizes and background traffic keep flowing so the
+	// probe loop and worker event pump stay alive underneath it.
+	if m.playground != nil {
+		switch sized := msg.(type) {
+		case tea.WindowSizeMsg:
+			m.playground.width = sized.Width
+		case registryLoadedMsg, serverToggledMsg, healthTickMsg, healthResultsMsg,
+			resourceResultsMsg, fleetResultsMsg, clearStatusMsg, workerFailedMsg:
+		default:
+			cmd, open := m.playground.updatePlayground(msg)
+			if !open {
+				m.playground = nil
+			}
+			return m, cmd
+		}
+	}
+	switch msg := msg.(type) {
+	case registryLoadedMsg:
+		recordUIEvent("registry_loaded", fmt.Sprintf("servers=%d err=%v", len(msg.servers), msg.err))
+		m.err = msg.err
+		if msg.err == nil {
+			m.servers = msg.servers
+			m.warnings = msg.warnings
+			m.rebuildItems()
+		}
+		return m, m.pump()
+
+	case serverToggledMsg:
+		recordUIEvent("server_toggled", fmt.Sprintf("name=%s enabled=%v err=%v", msg.name, msg.enabled, msg.err))
+		if msg.err != nil {
+			model, cmd := m.withStatus(statusError, fmt.Sprintf("Failed to toggle %s: %v", msg.name, msg.err))
+			return model, tea.Batch(cmd, model.pump())
+		}
+		state := "disabled"
+		if msg.enabled {
+			state = "enabled"
+		}
+		model, cmd := m.withStatus(statusSuccess, fmt.Sprintf("%s %s", msg.name, state))
+		return model, tea.Batch(cmd, model.background(loadRegistryCmd(m.registryPath, m.selector)), model.pump())
+
+	case workerFailedMsg:
+		recordUIEvent("workers_failed", msg.err.Error())
+		return m.withStatus(statusError, fmt.Sprintf("Background workers stopped: %v", msg.err))
+
+	case clearStatusMsg:
+		if msg.seq == m.status.seq {
+			m.status.text = ""
+		}
+		return m, nil
+
+	case healthTickMsg:
+		if len(m.servers) == 0 {
+			return m, nextHealthTick()
+		}
+		cmds := []tea.Cmd{m.background(probeHealthCmd(m.servers)), m.background(sampleResourcesCmd()), nextHealthTick()}
+		if m.fleet.Bus != "" {
+			cmds = append(cmds, m.background(collectFleetCmd(m.fleet)))
+		}
+		return m, tea.Batch(cmds...)
+
+	case healthResultsMsg:
+		m.health = msg
+		return m, m.pump()
+
+	case fleetResultsMsg:
+		if msg != nil {
+			m.fleetHosts = msg
+		}
+		return m, m.pump()
+
+	case resourceResultsMsg:
+		m.resources = msg
+		return m, m.pump()
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.help.Width = msg.Width
+		return m, nil
+
+	case tea.KeyMsg:
+		// msg.String() allocates; render it once for the trace and the
+		// chord comparisons below.
+		keyStr := msg.String()
+		recordUIEvent("key", keyStr)
+		if keyStr == "ctrl+d" {
+			path, err := dumpUIRing()
+			if err != nil {
+				return m.withStatus(statusError, fmt.Sprintf("Debug dump failed: %v", err))
+			}
+			return m.withStatus(statusSuccess, fmt.Sprintf("UI trace written to %s", path))
+		}
+		if m.filtering {
+			switch keyStr {
+			case "esc":
+				m.filtering = false
+				m.filter = ""
+			case "enter":
+				m.filtering = false
+			case "backspace":
+				if len(m.filter) > 0 {
+					m.filter = m.filter[:len(m.filter)-1]
+				}
+			default:
+				if msg.Type == tea.KeyRunes {
+					m.filter += string(msg.Runes)
+				}
+			}
+			m.cursor = 0
+			m.rebuildItems()
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Help):
+			m.showHelp = !m.showHelp
+			m.help.ShowAll = m.showHelp
+		case key.Matches(msg, m.keys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case key.Matches(msg, m.keys.Down):
+			if m.cursor < len(m.items)-1 {
+				m.cursor++
+			}
+		case key.Matches(msg, m.keys.Sort):
+			m.sort = (m.sort + 1) % sortModeCount
+			m.rebuildItems()
+		case key.Matches(msg, m.keys.Group):
+			m.grouped = !m.grouped
+			m.cursor = 0
+			m.rebuildItems()
+		case key.Matches(msg, m.keys.Collapse):
+			if item := m.currentItem(); item != nil && item.header != "" {
+				m.collapsed[item.header] = !m.collapsed[item.header]
+				m.rebuildItems()
+			}
+		case key.Matches(msg, m.keys.Toggle):
+			if item := m.currentItem(); item != nil && item.header == "" {
+				srv := m.servers[item.serverIx]
+				m.status = statusBar{
+					text: fmt.Sprintf("Toggling %s…", srv.Name),
+					kind: statusInfo,
+					seq:  m.status.seq + 1,
+				}
+				return m, m.background(toggleServerCmd(m.registryPath, srv.Name))
+			}
+		case key.Matches(msg, m.keys.Play):
+			if item := m.currentItem(); item != nil && item.header == "" {
+				srv := m.servers[item.serverIx]
+				m.playground = &playgroundModel{
+					server: srv,
+					phase:  playgroundLoading,
+					styles: m.styles,
+					width:  m.width,
+				}
+				return m, openPlaygroundCmd(srv)
+			}
+		case key.Matches(msg, m.keys.Search):
+			m.filtering = true
+			m.filter = ""
+		case key.Matches(msg, m.keys.Reload):
+			return m, m.background(loadRegistryCmd(m.registryPath, m.selector))
+		}
+	}
+	return m, nil
+}
+
+func toggleServerCmd(path, name string) tea.Cmd {
+	return func() tea.Msg {
+		var enabled bool
+		err := mutateServer(path, name, func(srv *MCPServer) error {
+			srv.Enabled = !srv.Enabled
+			enabled = srv.Enabled
+			return nil
+		})
+		if err != nil {
+			return serverToggledMsg{name: name, err: err}
+		}
+		// saveMCPRegistry invalidated the cache, so the reload triggered by
+		// this message sees the new data immediately — no sleep needed.
+		return serverToggledMsg{name: name, enabled: enabled}
+	}
+}
+
+// withStatus sets the status bar and schedules its expiry.
+func (m dashboardModel) withStatus(kind statusKind, text string) (dashboardModel, tea.Cmd) {
+	m.status.seq++
+	m.status.kind = kind
+	m.status.text = text
+	seq := m.status.seq
+	return m, tea.Tick(4*time.Second, func(time.Time) tea.Msg {
+		return clearStatusMsg{seq: seq}
+	})
+}
+
+func (m *dashboardModel) currentItem() *dashboardItem {
+	if m.cursor < 0 || m.cursor >= len(m.items) {
+		return nil
+	}
+	return &m.items[m.cursor]
+}
+
+// rebuildItems re-sorts the servers and regenerates the visible item list,
+// honoring grouped mode and collapsed headers.
+func (m *dashboardModel) rebuildItems() {
+	var order []int
+	if terms := strings.Fields(strings.ToLower(m.filter)); len(terms) > 0 {
+		// Search-ranked order: best match first, non-matches hidden.
+		type ranked struct{ ix, score int }
+		var matches []ranked
+		for i := range m.servers {
+			if score, _ := scoreServer(&m.servers[i], terms); score > 0 {
+				matches = append(matches, ranked{ix: i, score: score})
+			}
+		}
+		sort.SliceStable(matches, func(a, b int) bool { return matches[a].score > matches[b].score })
+		for _, match := range matches {
+			order = append(order, match.ix)
+		}
+		m.items = m.items[:0]
+		for _, ix := range order {
+			m.items = append(m.items, dashboardItem{serverIx: ix})
+		}
+		if m.cursor >= len(m.items) {
+			m.cursor = len(m.items) - 1
+		}
+		if m.cursor < 0 {
+			m.cursor = 0
+		}
+		return
+	}
+	// Reuse the scratch order slice: sort and group keys are pressed often
+	// and this path must stay allocation-light (see BenchmarkDashboardKeyUpdate).
+	if cap(m.orderScratch) < len(m.servers) {
+		m.orderScratch = make([]int, len(m.servers))
+	}
+	order = m.orderScratch[:len(m.servers)]
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		sa, sb := m.servers[order[a]], m.servers[order[b]]
+		switch m.sort {
+		case sortByStatus:
+			if sa.Status != sb.Status {
+				return sa.Status < sb.Status
+			}
+		case sortByCategory:
+			if sa.Category != sb.Category {
+				return sa.Category < sb.Category
+			}
+		case sortByToolCount:
+			if len(sa.Tools) != len(sb.Tools) {
+				return len(sa.Tools) > len(sb.Tools)
+			}
+		case sortByLastCheck:
+			if sa.LastHealthCheck != sb.LastHealthCheck {
+				return sa.LastHealthCheck > sb.LastHealthCheck
+			}
+		}
+		return sa.Name < sb.Name
+	})
+
+	m.items = m.items[:0]
+	if !m.grouped {
+		for _, ix := range order {
+			m.items = append(m.items, dashboardItem{serverIx: ix})
+		}
+	} else {
+		byCategory := map[string][]int{}
+		var categories []string
+		for _, ix := range order {
+			category := m.servers[ix].Category
+			if category == "" {
+				category = "uncategorized"
+			}
+			if _, seen := byCategory[category]; !seen {
+				categories = append(categories, category)
+			}
+			byCategory[category] = append(byCategory[category], ix)
+		}
+		sort.Strings(categories)
+		for _, category := range categories {
+			m.items = append(m.items, dashboardItem{header: category})
+			if m.collapsed[category] {
+				continue
+			}
+			for _, ix := range byCategory[category] {
+				m.items = append(m.items, dashboardItem{serverIx: ix})
+			}
+		}
+	}
+	if m.cursor >= len(m.items) {
+		m.cursor = len(m.items) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+// defaultDashboardWidth is assumed until the first WindowSizeMsg arrives.
+const defaultDashboardWidth = 100
+
+// tableColumns describes the fixed-width columns; the description column
+// absorbs whatever terminal width remains.
+var tableColumns = []struct {
+	title string
+	width int
+}{
+	{"NAME", 24},
+	{"CATEGORY", 14},
+	{"STATUS", 18},
+	{"HEALTH", 12},
+	{"TOOLS", 5},
+	{"ON", 3},
+}
+
+func (m dashboardModel) View() string {
+	if m.playground != nil {
+		return m.playground.view()
+	}
+	if m.err != nil {
+		return fmt.Sprintf("Error: %v\n\nPress q to quit.\n", m.err)
+	}
+	if len(m.servers) == 0 {
+		return "Loading registry...\n"
+	}
+	width := m.width
+	if width <= 0 {
+		width = defaultDashboardWidth
+	}
+
+	groupedNote := ""
+	if m.grouped {
+		groupedNote = ", grouped"
+	}
+	out := m.styles.title.Render("⚡ Machina MCP Dashboard") +
+		fmt.Sprintf("  (sort: %s%s)\n", m.sort, groupedNote)
+	if m.warnings > 0 {
+		out += m.styles.header.Render(fmt.Sprintf(
+			"⚠ %d registry conflict(s) — run 'devgen validate'", m.warnings)) + "\n"
+	}
+	if m.filtering || m.filter != "" {
+		prompt := "/" + m.filter
+		if m.filtering {
+			prompt += "▌"
+		}
+		out += m.styles.footer.Render(prompt) + "\n"
+	}
+	out += "\n"
+
+	// Header row plus the flexible description column. Fleet mode adds a
+	// HOST column listing the hosts whose snapshots include each server.
+	fixed := 0
+	header := ""
+	if len(m.fleetHosts) > 0 {
+		header += padTo("HOST", 16) + " "
+		fixed += 17
+	}
+	for _, col := range tableColumns {
+		header += padTo(col.title, col.width) + " "
+		fixed += col.width + 1
+	}
+	descWidth := width - fixed - 1
+	if descWidth < 10 {
+		descWidth = 10
+	}
+	out += m.styles.header.Render(header+padTo("DESCRIPTION", descWidth)) + "\n"
+
+	for i, item := range m.items {
+		if item.header != "" {
+			marker := "▼"
+			if m.collapsed[item.header] {
+				marker = "▶"
+			}
+			line := fmt.Sprintf("%s %s", marker, item.header)
+			if i == m.cursor {
+				line = m.styles.selected.Render(line)
+			} else {
+				line = m.styles.header.Render(line)
+			}
+			out += line + "\n"
+			continue
+		}
+		srv := m.servers[item.serverIx]
+		state := m.styles.disabled.Render(padTo("○", 3))
+		if srv.Enabled {
+			state = m.styles.enabled.Render(padTo("●", 3))
+		}
+		row := ""
+		if len(m.fleetHosts) > 0 {
+			row += padTo(m.fleetHostsFor(srv.Name), 16) + " "
+		}
+		row += padTo(srv.Name, 24) + " " +
+			padTo(srv.Category, 14) + " " +
+			padTo(srv.Status, 18) + " " +
+			m.renderHealthCell(srv.Name) + " " +
+			padTo(fmt.Sprintf("%d", len(srv.Tools)), 5) + " " +
+			state + " " +
+			padTo(srv.Description, descWidth)
+		if i == m.cursor {
+			row = m.styles.selected.Render(row)
+		}
+		out += row + "\n"
+	}
+
+	if item := m.currentItem(); item != nil && item.header == "" {
+		srv := m.servers[item.serverIx]
+		var res *procSample
+		if sample, ok := m.resources[srv.Name]; ok {
+			res = &sample
+		}
+		out += "\n" + renderServerCard(srv, res, width, m.styles) + "\n"
+	}
+	if m.status.text != "" {
+		style := m.styles.footer
+		switch m.status.kind {
+		case statusSuccess:
+			style = m.styles.enabled
+		case statusError:
+			style = m.styles.header
+		}
+		out += style.Render(m.status.text) + "\n"
+	}
+	out += m.help.View(m.keys) + "\n"
+	return out
+}
+
+// fleetHostsFor lists the hosts whose latest fleet snapshot includes the
+// named server; "-" means no host has published it yet.
+func (m dashboardModel) fleetHostsFor(name string) string {
+	hosts := make([]string, 0, len(m.fleetHosts))
+	for host, snapshot := range m.fleetHosts {
+		for _, srv := range snapshot.Servers {
+			if srv.Name == name {
+				hosts = append(hosts, host)
+				break
+			}
+		}
+	}
+	if len(hosts) == 0 {
+		return "-"
+	}
+	sort.Strings(hosts)
+	return strings.Join(hosts, ",")
+}
+
+// renderHealthCell renders the live probe indicator and last-checked age
+// for one server, fixed to the HEALTH column width.
+func (m dashboardModel) renderHealthCell(name string) string {
+	probe, ok := m.health[name]
+	if !ok {
+		return m.styles.disabled.Render(padTo("· -", 12))
+	}
+	cell := padTo(fmt.Sprintf("%s %s", healthIndicator(probe.State), healthAge(probe.CheckedAt)), 12)
+	switch probe.State {
+	case healthHealthy:
+		return m.styles.enabled.Render(cell)
+	case healthDegraded:
+		return m.styles.footer.Render(cell)
+	case healthUnreachable:
+		return m.styles.header.Render(cell)
+	}
+	return m.styles.disabled.Render(cell)
+}
+
+func healthIndicator(state healthState) string {
+	switch state {
+	case healthHealthy:
+		return "●"
+	case healthDegraded:
+		return "◐"
+	case healthUnreachable:
+		return "○"
+	}
+	return "·"
+}
+
+// padTo truncates or right-pads s to exactly width terminal cells. Width
+// is measured in display cells, not bytes or runes, so emoji and CJK text
+// keep the columns aligned.
+func padTo(s string, width int) string {
+	if runewidth.StringWidth(s) > width {
+		s = runewidth.Truncate(s, width, "…")
+	}
+	return runewidth.FillRight(s, width)
+}
+
+// renderServerCard renders the selected server's detail card, sized to the
+// live terminal width.
+func renderServerCard(srv MCPServer, res *procSample, width int, styles dashboardStyles) string {
+	state := styles.disabled.Render("○ disabled")
+	if srv.Enabled {
+		state = styles.enabled.Render("● enabled")
+	}
+	if inMaintenance(&srv) {
+		state = styles.footer.Render("◐ maintenance")
+	}
+	title := fmt.Sprintf("%s  %s", srv.Name, state)
+	meta := fmt.Sprintf("%s | %s | %d tools | %d resources | %d prompts",
+		srv.Category, srv.Status, len(srv.Tools), len(srv.Resources), len(srv.Prompts))
+
+	inner := width - 4 // border and padding
+	if inner < 20 {
+		inner = 20
+	}
+	// Wrap the description to the card width at word boundaries. wordwrap
+	// is ANSI- and rune-aware, so styled or multibyte text never gets cut
+	// mid-escape or mid-character.
+	wrapped := wordwrap.String(srv.Description, inner)
+
+	body := title + "\n" + meta
+	if inMaintenance(&srv) {
+		note := "in maintenance"
+		if srv.MaintenanceUntil != "" {
+			note += " until " + srv.MaintenanceUntil
+		}
+		body += "\n" + styles.footer.Render(note)
+	}
+	if srv.Status == statusQuarantined {
+		body += "\n" + styles.header.Render("QUARANTINED after a crash loop; run 'devgen start' to release")
+		for _, line := range tailServerLog(srv.Name, 5) {
+			body += "\n" + styles.footer.Render(padTo(line, inner))
+		}
+	}
+	if res != nil {
+		usage := fmt.Sprintf("cpu %.1f%% | rss %.1fM | fds %d", res.CPUPercent, res.RSSMegabytes, res.OpenFDs)
+		if overResourceLimits(&srv, *res) {
+			usage += styles.header.Render("  over limit")
+		}
+		body += "\n" + usage
+	}
+	if wrapped != "" {
+		body += "\n" + wrapped
+	}
+	return styles.card.Width(width - 2).Render(body)
+}
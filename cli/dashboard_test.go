@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/exp/golden"
+	"github.com/charmbracelet/x/exp/teatest"
+)
+
+// freshDashboardModel builds a model the way dashboardCmd does, but with
+// default config so the goldens don't depend on the machine's devgen.json.
+func freshDashboardModel(registryPath string) dashboardModel {
+	return dashboardModel{
+		registryPath: registryPath,
+		collapsed:    map[string]bool{},
+		keys:         keymapFromConfig(keymapConfig{}),
+		help:         help.New(),
+		styles:       newDashboardStyles(resolveTheme(themeConfig{})),
+	}
+}
+
+// dashboardFixtures exercises the layout's edge cases: a long Unicode
+// description that must wrap, an empty category, and a disabled server.
+func dashboardFixtures() []MCPServer {
+	return []MCPServer{
+		{
+			Name:        "context7",
+			Category:    "knowledge",
+			Status:      "active",
+			Description: "Documentation sourcing and semantic search über alles — a deliberately löng description with multibyte rünes that has to wrap across several lines of the detail card without splitting characters",
+			Tools:       []string{"search", "store", "crawl"},
+			Enabled:     true,
+		},
+		{
+			Name:        "surrealdb",
+			Category:    "database",
+			Status:      "active",
+			Description: "Vector database operations",
+			Tools:       []string{"query"},
+			Enabled:     true,
+		},
+		{
+			Name:        "scratch",
+			Status:      "inactive",
+			Description: "no category, disabled",
+			Enabled:     false,
+		},
+	}
+}
+
+// advance feeds a message through Update, keeping the concrete model type.
+func advance(t *testing.T, m dashboardModel, msg tea.Msg) dashboardModel {
+	t.Helper()
+	next, _ := m.Update(msg)
+	model, ok := next.(dashboardModel)
+	if !ok {
+		t.Fatalf("Update returned %T, want dashboardModel", next)
+	}
+	return model
+}
+
+func TestDashboardViewLoading(t *testing.T) {
+	m := freshDashboardModel("unused")
+	m = advance(t, m, tea.WindowSizeMsg{Width: 120, Height: 40})
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+func TestDashboardViewServers(t *testing.T) {
+	m := freshDashboardModel("unused")
+	m = advance(t, m, tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = advance(t, m, registryLoadedMsg{servers: dashboardFixtures()})
+	// Zero CheckedAt keeps the age column at "-" so the golden is stable.
+	m = advance(t, m, healthResultsMsg{
+		"context7":  {State: healthHealthy},
+		"surrealdb": {State: healthDegraded, Detail: "got status 503"},
+		"scratch":   {State: healthUnreachable},
+	})
+	m = advance(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+func TestDashboardViewNarrowWidth(t *testing.T) {
+	m := freshDashboardModel("unused")
+	m = advance(t, m, tea.WindowSizeMsg{Width: 60, Height: 20})
+	m = advance(t, m, registryLoadedMsg{servers: dashboardFixtures()})
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+func TestDashboardViewEmptyRegistry(t *testing.T) {
+	m := freshDashboardModel("unused")
+	m = advance(t, m, tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = advance(t, m, registryLoadedMsg{servers: nil})
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+func TestDashboardViewRegistryError(t *testing.T) {
+	m := freshDashboardModel("unused")
+	m = advance(t, m, registryLoadedMsg{err: os.ErrNotExist})
+	golden.RequireEqual(t, []byte(m.View()))
+}
+
+// TestDashboardProgram runs the full program loop against a real registry
+// file: the Init load must surface the servers, and quit must exit cleanly.
+func TestDashboardProgram(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("MACHINA_ROOT", root)
+	regPath := filepath.Join(root, registryFileName)
+	data, err := json.Marshal(&MCPRegistry{Version: "1.0", Servers: dashboardFixtures()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(regPath, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tm := teatest.NewTestModel(t, freshDashboardModel(regPath),
+		teatest.WithInitialTermSize(120, 40))
+	teatest.WaitFor(t, tm.Output(), func(out []byte) bool {
+		return containsAll(out, "context7", "surrealdb", "scratch")
+	}, teatest.WithDuration(5*time.Second))
+	tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	tm.WaitFinished(t, teatest.WithFinalTimeout(5*time.Second))
+}
+
+func containsAll(out []byte, wants ...string) bool {
+	for _, want := range wants {
+		if !bytes.Contains(out, []byte(want)) {
+			return false
+		}
+	}
+	return true
+}
+
+// benchmarkDashboardServers builds a registry large enough that sort and
+// cursor handling dominate the measurement.
+func benchmarkDashboardServers(n int) []MCPServer {
+	servers := make([]MCPServer, n)
+	for i := range servers {
+		servers[i] = MCPServer{
+			Name:        fmt.Sprintf("server-%04d", i),
+			Category:    fmt.Sprintf("category-%d", i%9),
+			Status:      "active",
+			Description: "benchmark fixture with a description long enough to wrap",
+			Tools:       []string{"tool_a", "tool_b", "tool_c"},
+			Enabled:     i%2 == 0,
+		}
+	}
+	return servers
+}
+
+// BenchmarkDashboardKeyUpdate guards keypress latency: cursor moves and
+// sort cycles (the rebuildItems path) must stay comfortably under 1ms.
+func BenchmarkDashboardKeyUpdate(b *testing.B) {
+	m := freshDashboardModel("unused")
+	m, _ = applyMsg(m, tea.WindowSizeMsg{Width: 120, Height: 40})
+	m, _ = applyMsg(m, registryLoadedMsg{servers: benchmarkDashboardServers(200)})
+	keys := []tea.KeyMsg{
+		{Type: tea.KeyDown},
+		{Type: tea.KeyDown},
+		{Type: tea.KeyRunes, Runes: []rune("s")},
+		{Type: tea.KeyUp},
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m, _ = applyMsg(m, keys[i%len(keys)])
+	}
+}
+
+func applyMsg(m dashboardModel, msg tea.Msg) (dashboardModel, tea.Cmd) {
+	model, cmd := m.Update(msg)
+	return model.(dashboardModel), cmd
+}
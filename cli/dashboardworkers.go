@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/sync/errgroup"
+)
+
+// The dashboard's background work — registry loads, probe sweeps,
+// resource sampling, toggles — runs in a small supervised worker pool
+// instead of ad-hoc goroutines. Work is submitted as functions producing
+// a tea.Msg; results come back over one events channel that the TUI
+// drains with a pump command, so key handling never waits on a slow
+// probe and a crashed worker surfaces as a status-bar error rather than
+// taking the program down.
+
+// dashboardWorkerCount bounds concurrent background operations.
+const dashboardWorkerCount = 4
+
+// workerRequest is one unit of background work.
+type workerRequest func() tea.Msg
+
+// workerFailedMsg reports that the worker pool died; the dashboard keeps
+// running, but background refreshes stop until restart.
+type workerFailedMsg struct{ err error }
+
+type dashboardWorkers struct {
+	cancel   context.CancelFunc
+	requests chan workerRequest
+	events   chan tea.Msg
+}
+
+func startDashboardWorkers() *dashboardWorkers {
+	ctx, cancel := context.WithCancel(context.Background())
+	group, ctx := errgroup.WithContext(ctx)
+	w := &dashboardWorkers{
+		cancel:   cancel,
+		requests: make(chan workerRequest, 16),
+		events:   make(chan tea.Msg, 16),
+	}
+	for i := 0; i < dashboardWorkerCount; i++ {
+		group.Go(func() error {
+			for {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case req := <-w.requests:
+					msg, err := runWorkerRequest(req)
+					if err != nil {
+						return err
+					}
+					select {
+					case w.events <- msg:
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
+			}
+		})
+	}
+	go func() {
+		if err := group.Wait(); err != nil && !errors.Is(err, context.Canceled) {
+			w.events <- workerFailedMsg{err: err}
+		}
+	}()
+	return w
+}
+
+// runWorkerRequest executes one request, converting a panic into an error
+// so the supervisor can report it instead of crashing the process.
+func runWorkerRequest(req workerRequest) (msg tea.Msg, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("background worker panicked: %v", r)
+		}
+	}()
+	return req(), nil
+}
+
+// submit queues work without ever blocking the UI goroutine: when the
+// queue is momentarily full the handoff moves to a goroutine.
+func (w *dashboardWorkers) submit(req workerRequest) {
+	select {
+	case w.requests <- req:
+	default:
+		go func() { w.requests <- req }()
+	}
+}
+
+// awaitEvent delivers the next background result to the TUI. The model
+// keeps exactly one of these commands in flight while the pool is alive.
+func (w *dashboardWorkers) awaitEvent() tea.Cmd {
+	return func() tea.Msg { return <-w.events }
+}
+
+func (w *dashboardWorkers) stop() {
+	w.cancel()
+}
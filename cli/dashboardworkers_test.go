@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// drainEvent reads one event from the pool with a deadline so a broken
+// pump fails the test instead of hanging it.
+func drainEvent(t *testing.T, w *dashboardWorkers) tea.Msg {
+	t.Helper()
+	select {
+	case msg := <-w.events:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("no worker event within 5s")
+		return nil
+	}
+}
+
+func TestDashboardWorkersDeliverResults(t *testing.T) {
+	w := startDashboardWorkers()
+	defer w.stop()
+
+	for i := 0; i < 10; i++ {
+		i := i
+		w.submit(func() tea.Msg { return fmt.Sprintf("result-%d", i) })
+	}
+	seen := map[tea.Msg]bool{}
+	for i := 0; i < 10; i++ {
+		seen[drainEvent(t, w)] = true
+	}
+	if len(seen) != 10 {
+		t.Errorf("got %d distinct results, want 10", len(seen))
+	}
+}
+
+func TestDashboardWorkersSurfacePanics(t *testing.T) {
+	w := startDashboardWorkers()
+	defer w.stop()
+
+	w.submit(func() tea.Msg { panic("probe exploded") })
+	msg := drainEvent(t, w)
+	failed, ok := msg.(workerFailedMsg)
+	if !ok {
+		t.Fatalf("got %T, want workerFailedMsg", msg)
+	}
+	if failed.err == nil || failed.err.Error() != "background worker panicked: probe exploded" {
+		t.Errorf("err = %v", failed.err)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// uiEventRingSize bounds the in-memory trace of UI events. The ring
+// replaces the old per-keystroke key_debug.log writes: no file I/O on the
+// hot path and nothing littering the working directory.
+const uiEventRingSize = 256
+
+type uiEvent struct {
+	At     time.Time
+	Kind   string
+	Detail string
+}
+
+// uiEventRing is a fixed-size ring of recent UI events.
+type uiEventRing struct {
+	mu     sync.Mutex
+	events [uiEventRingSize]uiEvent
+	next   int
+	count  int
+}
+
+var uiRing uiEventRing
+
+// recordUIEvent appends one event to the ring, overwriting the oldest.
+func recordUIEvent(kind, detail string) {
+	uiRing.mu.Lock()
+	defer uiRing.mu.Unlock()
+	uiRing.events[uiRing.next] = uiEvent{At: time.Now(), Kind: kind, Detail: detail}
+	uiRing.next = (uiRing.next + 1) % uiEventRingSize
+	if uiRing.count < uiEventRingSize {
+		uiRing.count++
+	}
+}
+
+// snapshotUIEvents returns the ring's contents, oldest first.
+func snapshotUIEvents() []uiEvent {
+	uiRing.mu.Lock()
+	defer uiRing.mu.Unlock()
+	events := make([]uiEvent, 0, uiRing.count)
+	start := uiRing.next - uiRing.count
+	for i := 0; i < uiRing.count; i++ {
+		events = append(events, uiRing.events[(start+i+uiEventRingSize)%uiEventRingSize])
+	}
+	return events
+}
+
+// uiDebugDumpPrefix names ring dumps in the temp directory, where
+// 'devgen debug dump' finds them afterwards.
+const uiDebugDumpPrefix = "devgen-ui-debug-"
+
+// dumpUIRing writes the ring to a timestamped file in the temp directory
+// and returns its path.
+func dumpUIRing() (string, error) {
+	events := snapshotUIEvents()
+	if len(events) == 0 {
+		return "", fmt.Errorf("no UI events recorded yet")
+	}
+	path := filepath.Join(os.TempDir(),
+		fmt.Sprintf("%s%s.log", uiDebugDumpPrefix, time.Now().Format("20060102-150405")))
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	for _, ev := range events {
+		fmt.Fprintf(f, "%s %-12s %s\n", ev.At.Format(time.RFC3339Nano), ev.Kind, ev.Detail)
+	}
+	return path, nil
+}
+
+var debugCmd = &cobra.Command{
+	Use:   "debug",
+	Short: "Diagnostic helpers",
+}
+
+var debugDumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Print the most recent UI event trace",
+	Long: `Prints the newest UI event dump written by the dashboard's ctrl+d
+key. The trace lives in an in-memory ring while the dashboard runs; ctrl+d
+snapshots it to the temp directory for this command to read.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		matches, err := filepath.Glob(filepath.Join(os.TempDir(), uiDebugDumpPrefix+"*.log"))
+		if err != nil {
+			return err
+		}
+		if len(matches) == 0 {
+			return fmt.Errorf("no UI event dumps found; press ctrl+d in the dashboard to write one")
+		}
+		sort.Strings(matches)
+		newest := matches[len(matches)-1]
+		data, err := os.ReadFile(newest)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("# %s\n", newest)
+		os.Stdout.Write(data)
+		return nil
+	},
+}
+
+func init() {
+	debugCmd.AddCommand(debugDumpCmd)
+	rootCmd.AddCommand(debugCmd)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// writeDiagnosticsBundle collects everything useful for a bug report into
+// one file in the state directory: versions, the reason, a stack trace,
+// recent UI events, and a hash of the registry (the hash identifies the
+// revision without copying server metadata into bug attachments).
+func writeDiagnosticsBundle(reason string, stack []byte) (string, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("diagnostics-%s.txt", time.Now().Format("20060102-150405")))
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	fmt.Fprintf(f, "devgen %s (commit %s, built %s)\n", devgenVersion, buildCommit, buildDate)
+	fmt.Fprintf(f, "%s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(f, "time: %s\n", time.Now().UTC().Format(time.RFC3339))
+	fmt.Fprintf(f, "reason: %s\n", reason)
+
+	if regPath, err := registryPath(); err == nil {
+		if data, err := os.ReadFile(regPath); err == nil {
+			fmt.Fprintf(f, "registry: %s (sha256 %x, %d bytes)\n", regPath, sha256.Sum256(data), len(data))
+		} else {
+			fmt.Fprintf(f, "registry: %s (unreadable: %v)\n", regPath, err)
+		}
+	}
+
+	if events := snapshotUIEvents(); len(events) > 0 {
+		fmt.Fprintf(f, "\n--- recent UI events ---\n")
+		for _, ev := range events {
+			fmt.Fprintf(f, "%s %-12s %s\n", ev.At.Format(time.RFC3339Nano), ev.Kind, ev.Detail)
+		}
+	}
+
+	fmt.Fprintf(f, "\n--- stack ---\n")
+	f.Write(stack)
+	return path, nil
+}
+
+// recoverWithDiagnostics is deferred around long-running components (the
+// TUI, the daemon). On panic it writes a diagnostics bundle, tells the
+// user where to find it, and exits non-zero instead of dumping a raw
+// stack over a half-drawn terminal.
+func recoverWithDiagnostics(component string) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	path, err := writeDiagnosticsBundle(fmt.Sprintf("panic in %s: %v", component, r), debug.Stack())
+	if err != nil {
+		// The bundle failed too; the raw panic is all we have left.
+		fmt.Fprintf(os.Stderr, "devgen: panic in %s: %v\n%s", component, r, debug.Stack())
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "devgen: panic in %s: %v\ndiagnostics written to %s — please attach it to a bug report\n",
+		component, r, path)
+	os.Exit(1)
+}
+
+var diagnosticsCmd = &cobra.Command{
+	Use:   "diagnostics",
+	Short: "Collect diagnostic information",
+}
+
+var diagnosticsCollectCmd = &cobra.Command{
+	Use:   "collect",
+	Short: "Write a diagnostics bundle for bug reports",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stack := make([]byte, 1<<20)
+		stack = stack[:runtime.Stack(stack, true)]
+		path, err := writeDiagnosticsBundle("manual collection", stack)
+		if err != nil {
+			return err
+		}
+		fmt.Println(path)
+		return nil
+	},
+}
+
+func init() {
+	diagnosticsCmd.AddCommand(diagnosticsCollectCmd)
+	rootCmd.AddCommand(diagnosticsCmd)
+}
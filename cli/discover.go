@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/grandcat/zeroconf"
+	"github.com/spf13/cobra"
+)
+
+// mdnsServiceType is the DNS-SD service type MCP servers advertise under.
+const mdnsServiceType = "_mcp._tcp"
+
+// discoveredServer is one candidate found by a discovery mode.
+type discoveredServer struct {
+	Name        string
+	Endpoint    string
+	Description string
+}
+
+var (
+	discoverTimeoutFlag time.Duration
+	discoverPortsFlag   string
+)
+
+var discoverCmd = &cobra.Command{
+	Use:   "discover",
+	Short: "Discover MCP servers via mDNS or localhost port scanning",
+	Long: `Browses mDNS/Bonjour for MCP servers advertising the ` + mdnsServiceType + `
+service on the LAN and offers to register each one in the registry.
+
+With --ports, probes localhost ports for MCP-over-HTTP endpoints instead,
+verifying each candidate with an initialize request.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if discoverPortsFlag != "" {
+			lo, hi, err := parsePortRange(discoverPortsFlag)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Scanning localhost ports %d-%d for MCP servers...\n", lo, hi)
+			found := scanPorts(lo, hi)
+			if len(found) == 0 {
+				fmt.Println("No MCP servers found.")
+				return nil
+			}
+			for _, candidate := range found {
+				fmt.Printf("Found %s at %s\n", candidate.Name, candidate.Endpoint)
+			}
+			return offerRegistration(found)
+		}
+
+		resolver, err := zeroconf.NewResolver(nil)
+		if err != nil {
+			return fmt.Errorf("starting mDNS resolver: %w", err)
+		}
+
+		entries := make(chan *zeroconf.ServiceEntry)
+		var found []discoveredServer
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for entry := range entries {
+				if len(entry.AddrIPv4) == 0 {
+					continue
+				}
+				candidate := discoveredServer{
+					Name:     strings.ToLower(entry.Instance),
+					Endpoint: fmt.Sprintf("http://%s:%d", entry.AddrIPv4[0], entry.Port),
+				}
+				for _, txt := range entry.Text {
+					if desc, ok := strings.CutPrefix(txt, "description="); ok {
+						candidate.Description = desc
+					}
+				}
+				found = append(found, candidate)
+				fmt.Printf("Found %s at %s\n", candidate.Name, candidate.Endpoint)
+			}
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), discoverTimeoutFlag)
+		defer cancel()
+		if err := resolver.Browse(ctx, mdnsServiceType, "local.", entries); err != nil {
+			return fmt.Errorf("mDNS browse failed: %w", err)
+		}
+		<-ctx.Done()
+		<-done
+
+		if len(found) == 0 {
+			fmt.Println("No MCP servers found on the local network.")
+			return nil
+		}
+		return offerRegistration(found)
+	},
+}
+
+func init() {
+	discoverCmd.Flags().DurationVar(&discoverTimeoutFlag, "timeout", 5*time.Second, "how long to browse before reporting")
+	discoverCmd.Flags().StringVar(&discoverPortsFlag, "ports", "", "scan localhost ports instead of mDNS (e.g. 3000-9000)")
+	rootCmd.AddCommand(discoverCmd)
+}
+
+// offerRegistration interactively asks, per discovered server, whether to
+// add it to the registry.
+func offerRegistration(found []discoveredServer) error {
+	path, err := registryPath()
+	if err != nil {
+		return err
+	}
+	reg, err := loadMCPRegistry(path)
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	changed := false
+	for _, candidate := range found {
+		if reg.findServer(candidate.Name) != nil {
+			fmt.Printf("%s is already registered, skipping\n", candidate.Name)
+			continue
+		}
+		fmt.Printf("Register %s (%s)? [y/N] ", candidate.Name, candidate.Endpoint)
+		answer, _ := reader.ReadString('\n')
+		if strings.ToLower(strings.TrimSpace(answer)) != "y" {
+			continue
+		}
+		reg.Servers = append(reg.Servers, MCPServer{
+			Name:        candidate.Name,
+			Endpoint:    candidate.Endpoint,
+			Description: candidate.Description,
+			Status:      "discovered",
+			Enabled:     true,
+		})
+		changed = true
+		fmt.Printf("Registered %s\n", candidate.Name)
+	}
+	if changed {
+		return saveMCPRegistry(path, reg)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+// devgen docs generates reference documentation straight from the command
+// tree, so man pages shipped by packagers and the wiki's command
+// reference never drift from the source of truth.
+
+var docsOutputFlag string
+
+var docsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Generate reference documentation from the command tree",
+}
+
+var docsManCmd = &cobra.Command{
+	Use:   "man",
+	Short: "Write man pages for every devgen command",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := os.MkdirAll(docsOutputFlag, 0o755); err != nil {
+			return err
+		}
+		header := &doc.GenManHeader{
+			Title:   "DEVGEN",
+			Section: "1",
+			Source:  "machina " + devgenVersion,
+			Manual:  "Machina Manual",
+		}
+		if err := doc.GenManTree(rootCmd, header, docsOutputFlag); err != nil {
+			return err
+		}
+		fmt.Printf("Man pages written to %s\n", docsOutputFlag)
+		return nil
+	},
+}
+
+var docsMarkdownCmd = &cobra.Command{
+	Use:   "markdown",
+	Short: "Write one markdown reference page per devgen command",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := os.MkdirAll(docsOutputFlag, 0o755); err != nil {
+			return err
+		}
+		if err := doc.GenMarkdownTree(rootCmd, docsOutputFlag); err != nil {
+			return err
+		}
+		fmt.Printf("Markdown reference written to %s\n", docsOutputFlag)
+		return nil
+	},
+}
+
+func init() {
+	docsCmd.PersistentFlags().StringVarP(&docsOutputFlag, "output", "o", "docs",
+		"directory to write generated files into")
+	docsCmd.AddCommand(docsManCmd, docsMarkdownCmd)
+	rootCmd.AddCommand(docsCmd)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dryRunFlag suppresses every mutation: registry and managed-state writes
+// print the diff they would apply, and process control commands report
+// what they would do instead of doing it.
+var dryRunFlag bool
+
+// printDryRunDiff shows the change a write would have made to a file.
+func printDryRunDiff(path, before, after string) {
+	fmt.Printf("dry-run: would write %s:\n", path)
+	for _, line := range diffLines(before, after) {
+		fmt.Println(line)
+	}
+}
+
+// diffLines produces a minimal line diff (deletions prefixed "-",
+// additions "+") between two documents, using the standard LCS table.
+func diffLines(before, after string) []string {
+	a := strings.Split(strings.TrimSuffix(before, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(after, "\n"), "\n")
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	var out []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, "- "+a[i])
+			i++
+		default:
+			out = append(out, "+ "+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, "- "+a[i])
+	}
+	for ; j < len(b); j++ {
+		out = append(out, "+ "+b[j])
+	}
+	return out
+}
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&dryRunFlag, "dry-run", false,
+		"print the changes a command would make without applying them")
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/devq-ai/machina/cli/internal/testutil"
+)
+
+// TestMain lets the test binary double as a scripted stdio MCP server:
+// when the testutil script environment variable is set, the process
+// serves MCP over stdin/stdout instead of running tests. That gives the
+// mcp:// health check and the stdio client a real subprocess speaking
+// real JSON-RPC to talk to, with no external dependencies.
+func TestMain(m *testing.M) {
+	if testutil.RunMainIfScripted() {
+		return
+	}
+	os.Exit(m.Run())
+}
+
+// scriptedServer builds a registry entry whose launch command re-execs
+// this test binary as a fake MCP server running the script.
+func scriptedServer(t *testing.T, name string, script testutil.Script) MCPServer {
+	t.Helper()
+	command, env, err := testutil.ServerCommand(script)
+	if err != nil {
+		t.Fatalf("ServerCommand: %v", err)
+	}
+	return MCPServer{
+		Name:     name,
+		Endpoint: "stdio://" + command,
+		Launch:   &LaunchSpec{Command: command, Env: env},
+		Enabled:  true,
+	}
+}
+
+func TestHealthCheckHTTPEndToEnd(t *testing.T) {
+	hs := testutil.NewHealthServer(http.StatusOK, 0)
+	defer hs.Close()
+
+	srv := &MCPServer{Name: "fake-http", Endpoint: hs.URL, HealthCheck: hs.URL}
+	if result := probeServer(srv); result.State != healthHealthy {
+		t.Fatalf("healthy endpoint: got %s (%s), want healthy", result.State, result.Detail)
+	}
+
+	hs.SetStatus(http.StatusServiceUnavailable)
+	if result := probeServer(srv); result.State != healthDegraded {
+		t.Fatalf("503 endpoint: got %s (%s), want degraded", result.State, result.Detail)
+	}
+
+	hs.SetStatus(http.StatusOK)
+	hs.SetLatency(300 * time.Millisecond)
+	srv.HealthCheck = hs.URL + "?timeout=50ms"
+	if result := probeServer(srv); result.State != healthUnreachable {
+		t.Fatalf("slow endpoint: got %s (%s), want unreachable", result.State, result.Detail)
+	}
+}
+
+func TestHealthCheckMCPEndToEnd(t *testing.T) {
+	srv := scriptedServer(t, "fake-mcp", testutil.Script{})
+	srv.HealthCheck = "mcp://"
+	if result := probeServer(&srv); result.State != healthHealthy {
+		t.Fatalf("responsive server: got %s (%s), want healthy", result.State, result.Detail)
+	}
+
+	failing := scriptedServer(t, "fake-mcp-noping", testutil.Script{
+		FailMethods: map[string]bool{"ping": true},
+	})
+	failing.HealthCheck = "mcp://"
+	if result := probeServer(&failing); result.State != healthDegraded {
+		t.Fatalf("failing ping: got %s (%s), want degraded", result.State, result.Detail)
+	}
+}
+
+func TestPlaygroundEndToEnd(t *testing.T) {
+	srv := scriptedServer(t, "fake-play", testutil.Script{Tools: []string{"echo"}})
+	p := &playgroundModel{server: srv, phase: playgroundLoading}
+
+	opened, ok := openPlaygroundCmd(srv)().(playgroundOpenedMsg)
+	if !ok || opened.err != nil {
+		t.Fatalf("opening playground: %+v", opened)
+	}
+	defer p.close()
+	p.updatePlayground(opened)
+	if p.phase != playgroundPickTool || len(p.tools) != 1 || p.tools[0].Name != "echo" {
+		t.Fatalf("after open: phase=%d tools=%+v", p.phase, p.tools)
+	}
+
+	p.updatePlayground(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.phase != playgroundForm {
+		t.Fatalf("after tool selection: phase=%d", p.phase)
+	}
+	cmd, _ := p.updatePlayground(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || p.phase != playgroundCalling {
+		t.Fatalf("after submit: phase=%d cmd=%v", p.phase, cmd)
+	}
+	p.updatePlayground(cmd())
+	if p.phase != playgroundResult {
+		t.Fatalf("after call: phase=%d err=%v", p.phase, p.err)
+	}
+}
+
+func TestToggleEndToEnd(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("MACHINA_ROOT", root)
+	regPath := filepath.Join(root, registryFileName)
+
+	reg := &MCPRegistry{
+		Version: "1.0",
+		Servers: []MCPServer{scriptedServer(t, "fake-toggle", testutil.Script{})},
+	}
+	data, err := json.MarshalIndent(reg, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(regPath, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err = mutateServer(regPath, "fake-toggle", func(srv *MCPServer) error {
+		srv.Enabled = false
+		srv.Status = "inactive"
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("mutateServer: %v", err)
+	}
+
+	after, err := loadMCPRegistry(regPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := after.findServer("fake-toggle")
+	if srv == nil {
+		t.Fatal("server vanished after toggle")
+	}
+	if srv.Enabled || srv.Status != "inactive" {
+		t.Fatalf("toggle not persisted: enabled=%v status=%q", srv.Enabled, srv.Status)
+	}
+	if after.Revision == reg.Revision {
+		t.Fatalf("revision not bumped: still %d", after.Revision)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// envFlagAliases gives selected flags a more descriptive variable name than
+// the generic DEVGEN_<FLAG> mapping would.
+var envFlagAliases = map[string]string{
+	"url": "DEVGEN_REGISTRY_URL",
+}
+
+// flagEnvName maps a flag name to its environment variable: --max-sessions
+// becomes DEVGEN_MAX_SESSIONS.
+func flagEnvName(name string) string {
+	return "DEVGEN_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// bindEnvOverrides applies DEVGEN_* environment variables to flags left
+// unset on the command line, so containerized and CI usage doesn't need
+// flag plumbing. Explicit flags always win.
+func bindEnvOverrides(cmd *cobra.Command) error {
+	var bindErr error
+	apply := func(f *pflag.Flag) {
+		if f.Changed {
+			return
+		}
+		env := envFlagAliases[f.Name]
+		value, ok := os.LookupEnv(env)
+		if !ok {
+			env = flagEnvName(f.Name)
+			value, ok = os.LookupEnv(env)
+		}
+		if !ok {
+			return
+		}
+		if err := f.Value.Set(value); err != nil {
+			if bindErr == nil {
+				bindErr = fmt.Errorf("%s=%q: %w", env, value, err)
+			}
+			return
+		}
+		// Mark the flag set so config fallbacks don't override it.
+		f.Changed = true
+		debugf("env: %s applied to --%s", env, f.Name)
+	}
+	cmd.InheritedFlags().VisitAll(apply)
+	cmd.Flags().VisitAll(apply)
+	return bindErr
+}
+
+// rootPreRun is the shared persistent pre-run: commands that define their
+// own PersistentPreRunE must call it first, since cobra only runs the
+// nearest hook.
+func rootPreRun(cmd *cobra.Command) error {
+	if err := bindEnvOverrides(cmd); err != nil {
+		return err
+	}
+	return applyColorPreference(noColorFlag, colorFlag)
+}
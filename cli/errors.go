@@ -0,0 +1,60 @@
+package main
+
+import "errors"
+
+// Sentinel errors for the failure modes scripts most often branch on.
+// Wrap them with fmt.Errorf("%w: ...") so errors.Is keeps matching, and
+// main maps them to documented exit codes:
+//
+//	0  success
+//	1  generic failure
+//	2  registry file not found
+//	3  server not found in registry
+//	4  health check failed
+//	5  authentication failed
+//	6  registry write conflict (concurrent writer won)
+var (
+	errRegistryNotFound  = errors.New("registry file not found")
+	errServerNotFound    = errors.New("server not found in registry")
+	errHealthCheckFailed = errors.New("health check failed")
+	errAuthFailed        = errors.New("authentication failed")
+)
+
+// serverNotFoundError wraps errServerNotFound with the requested name.
+func serverNotFoundError(name string) error {
+	return wrapNamed(errServerNotFound, name)
+}
+
+func wrapNamed(sentinel error, name string) error {
+	return &namedError{sentinel: sentinel, name: name}
+}
+
+// namedError attaches a subject to a sentinel while keeping errors.Is
+// matching against the sentinel.
+type namedError struct {
+	sentinel error
+	name     string
+}
+
+func (e *namedError) Error() string { return e.sentinel.Error() + ": " + e.name }
+func (e *namedError) Unwrap() error { return e.sentinel }
+
+// exitCodeFor maps an error to the documented exit code.
+func exitCodeFor(err error) int {
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, errRegistryNotFound):
+		return 2
+	case errors.Is(err, errServerNotFound):
+		return 3
+	case errors.Is(err, errHealthCheckFailed):
+		return 4
+	case errors.Is(err, errAuthFailed):
+		return 5
+	case errors.Is(err, errRegistryConflict):
+		return 6
+	default:
+		return 1
+	}
+}
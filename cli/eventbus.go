@@ -0,0 +1,278 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// The event bus decouples notification logic from command code: call
+// sites publish state changes and configured sinks decide where they go.
+// Sinks exist for stdout, files, webhooks, and NATS; services with an
+// HTTP ingest endpoint (Logfire included) are reached through the webhook
+// sink. Each sink can filter on event kinds so a pager webhook sees only
+// health failures while an audit file records everything.
+
+// busEvent is one state-change notification.
+type busEvent struct {
+	Kind   string            `json:"kind"`
+	Server string            `json:"server,omitempty"`
+	Fields map[string]string `json:"fields,omitempty"`
+	At     string            `json:"at"`
+}
+
+// eventSink consumes bus events; delivery failures are logged, never
+// fatal.
+type eventSink interface {
+	consume(busEvent) error
+}
+
+// sinkConfig configures one sink in the events config block.
+type sinkConfig struct {
+	// Kind is stdout, file, webhook, or nats.
+	Kind string `json:"kind"`
+	// Target is the file path, webhook URL, or NATS server URL; unused
+	// for stdout.
+	Target string `json:"target,omitempty"`
+	// Subject overrides the NATS subject (default devgen.events).
+	Subject string `json:"subject,omitempty"`
+	// Events filters on event kinds; empty means all.
+	Events []string `json:"events,omitempty"`
+}
+
+type eventsConfig struct {
+	Sinks []sinkConfig `json:"sinks,omitempty"`
+}
+
+// sinkEntry pairs a sink with its compiled filter.
+type sinkEntry struct {
+	sink   eventSink
+	filter map[string]bool // nil = all kinds
+	label  string
+}
+
+func (e *sinkEntry) wants(kind string) bool {
+	return e.filter == nil || e.filter[kind]
+}
+
+type eventBus struct {
+	mu       sync.Mutex
+	sinks    []*sinkEntry
+	ch       chan busEvent
+	inflight sync.WaitGroup
+}
+
+var (
+	busOnce    sync.Once
+	defaultBus *eventBus
+)
+
+// publishEvent hands one state change to the bus. The first publish
+// builds the bus from config; without configured sinks events are
+// dropped, keeping plain commands free of any overhead.
+func publishEvent(kind, server string, fields map[string]string) {
+	busOnce.Do(initEventBus)
+	if defaultBus == nil {
+		return
+	}
+	defaultBus.publish(busEvent{
+		Kind:   kind,
+		Server: server,
+		Fields: fields,
+		At:     time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+func initEventBus() {
+	cfg, err := loadConfig()
+	if err != nil || len(cfg.Events.Sinks) == 0 {
+		return
+	}
+	bus := newEventBus()
+	for _, sc := range cfg.Events.Sinks {
+		entry, err := buildSink(sc)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: events sink %s: %v (sink disabled)\n", sc.Kind, err)
+			continue
+		}
+		bus.attach(entry)
+	}
+	defaultBus = bus
+}
+
+func newEventBus() *eventBus {
+	bus := &eventBus{ch: make(chan busEvent, 64)}
+	go bus.run()
+	return bus
+}
+
+func (b *eventBus) attach(entry *sinkEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sinks = append(b.sinks, entry)
+}
+
+// publish never blocks the caller: when the buffer is full the event is
+// dropped with a debug note rather than stalling a health sweep.
+func (b *eventBus) publish(event busEvent) {
+	b.inflight.Add(1)
+	select {
+	case b.ch <- event:
+	default:
+		b.inflight.Done()
+		debugf("eventbus: buffer full, dropped %s for %s", event.Kind, event.Server)
+	}
+}
+
+func (b *eventBus) run() {
+	for event := range b.ch {
+		b.mu.Lock()
+		sinks := append([]*sinkEntry(nil), b.sinks...)
+		b.mu.Unlock()
+		for _, entry := range sinks {
+			if !entry.wants(event.Kind) {
+				continue
+			}
+			if err := entry.sink.consume(event); err != nil {
+				debugf("eventbus: %s sink: %v", entry.label, err)
+			}
+		}
+		b.inflight.Done()
+	}
+}
+
+// drainEventBus waits for buffered events to reach their sinks, so a
+// short-lived command doesn't exit before its mutation events land.
+func drainEventBus(timeout time.Duration) {
+	if defaultBus == nil {
+		return
+	}
+	done := make(chan struct{})
+	go func() {
+		defaultBus.inflight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		debugf("eventbus: drain timed out after %s", timeout)
+	}
+}
+
+func buildSink(cfg sinkConfig) (*sinkEntry, error) {
+	var sink eventSink
+	switch cfg.Kind {
+	case "stdout":
+		sink = writerSink{w: os.Stdout}
+	case "file":
+		if cfg.Target == "" {
+			return nil, fmt.Errorf("file sink needs a target path")
+		}
+		sink = &fileSink{path: cfg.Target}
+	case "webhook":
+		if cfg.Target == "" {
+			return nil, fmt.Errorf("webhook sink needs a target URL")
+		}
+		sink = &webhookSink{url: cfg.Target}
+	case "nats":
+		if cfg.Target == "" {
+			return nil, fmt.Errorf("nats sink needs a server URL")
+		}
+		conn, err := nats.Connect(cfg.Target)
+		if err != nil {
+			return nil, fmt.Errorf("connecting to %s: %w", cfg.Target, err)
+		}
+		subject := cfg.Subject
+		if subject == "" {
+			subject = "devgen.events"
+		}
+		sink = &natsSink{conn: conn, subject: subject}
+	default:
+		return nil, fmt.Errorf("unknown sink kind %q (want stdout, file, webhook, or nats)", cfg.Kind)
+	}
+	entry := &sinkEntry{sink: sink, label: cfg.Kind}
+	if len(cfg.Events) > 0 {
+		entry.filter = map[string]bool{}
+		for _, kind := range cfg.Events {
+			entry.filter[kind] = true
+		}
+	}
+	return entry, nil
+}
+
+// writerSink emits one JSON line per event.
+type writerSink struct {
+	w *os.File
+}
+
+func (s writerSink) consume(event busEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = s.w.Write(append(data, '\n'))
+	return err
+}
+
+// fileSink appends JSON lines, reopening per event so rotation works.
+type fileSink struct {
+	path string
+}
+
+func (s *fileSink) consume(event busEvent) error {
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return writerSink{w: f}.consume(event)
+}
+
+// webhookSink POSTs each event as JSON through the shared transport and
+// retry policy.
+type webhookSink struct {
+	url string
+}
+
+func (s *webhookSink) consume(event busEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	resp, err := outboundRetryPolicy().doHTTP(outboundHTTPClient(10*time.Second), func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// natsSink publishes each event to one subject.
+type natsSink struct {
+	conn    *nats.Conn
+	subject string
+}
+
+func (s *natsSink) consume(event busEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return s.conn.Publish(s.subject, payload)
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// memorySink records consumed events for assertions.
+type memorySink struct {
+	events chan busEvent
+}
+
+func (s *memorySink) consume(event busEvent) error {
+	s.events <- event
+	return nil
+}
+
+func awaitBusEvent(t *testing.T, ch chan busEvent) busEvent {
+	t.Helper()
+	select {
+	case got := <-ch:
+		return got
+	case <-time.After(5 * time.Second):
+		t.Fatal("no bus event within 5s")
+		return busEvent{}
+	}
+}
+
+func TestEventBusFanOutAndFiltering(t *testing.T) {
+	bus := newEventBus()
+	all := &memorySink{events: make(chan busEvent, 8)}
+	failsOnly := &memorySink{events: make(chan busEvent, 8)}
+	bus.attach(&sinkEntry{sink: all, label: "all"})
+	bus.attach(&sinkEntry{sink: failsOnly, label: "fails", filter: map[string]bool{"health_fail": true}})
+
+	bus.publish(busEvent{Kind: "status_change", Server: "context7", At: "t1"})
+	bus.publish(busEvent{Kind: "health_fail", Server: "surrealdb", At: "t2"})
+
+	if got := awaitBusEvent(t, all.events); got.Kind != "status_change" {
+		t.Errorf("unfiltered sink first event = %q, want status_change", got.Kind)
+	}
+	if got := awaitBusEvent(t, all.events); got.Kind != "health_fail" {
+		t.Errorf("unfiltered sink second event = %q, want health_fail", got.Kind)
+	}
+	got := awaitBusEvent(t, failsOnly.events)
+	if got.Kind != "health_fail" || got.Server != "surrealdb" {
+		t.Errorf("filtered sink event = %+v, want the health_fail", got)
+	}
+	select {
+	case extra := <-failsOnly.events:
+		t.Errorf("filtered sink received unwanted event %+v", extra)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestFileSinkAppendsJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	sink := &fileSink{path: path}
+	for _, kind := range []string{"server_start", "health_fail"} {
+		if err := sink.consume(busEvent{Kind: kind, Server: "ptolemies", At: "t"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var kinds []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event busEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("line %q is not valid JSON: %v", scanner.Text(), err)
+		}
+		kinds = append(kinds, event.Kind)
+	}
+	if len(kinds) != 2 || kinds[0] != "server_start" || kinds[1] != "health_fail" {
+		t.Errorf("file sink wrote kinds %v", kinds)
+	}
+}
+
+func TestWebhookSinkPostsEvent(t *testing.T) {
+	received := make(chan busEvent, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		var event busEvent
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("decoding webhook body: %v", err)
+		}
+		received <- event
+	}))
+	defer srv.Close()
+
+	sink := &webhookSink{url: srv.URL}
+	if err := sink.consume(busEvent{Kind: "status_change", Server: "bayes", Fields: map[string]string{"from": "active", "to": "locked"}, At: "t"}); err != nil {
+		t.Fatal(err)
+	}
+	got := awaitBusEvent(t, received)
+	if got.Server != "bayes" || got.Fields["to"] != "locked" {
+		t.Errorf("webhook received %+v", got)
+	}
+}
+
+func TestWebhookSinkReportsServerErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	sink := &webhookSink{url: srv.URL}
+	if err := sink.consume(busEvent{Kind: "health_fail", At: "t"}); err == nil {
+		t.Error("consume succeeded against a 500 endpoint")
+	}
+}
+
+func TestBuildSinkValidation(t *testing.T) {
+	for _, cfg := range []sinkConfig{
+		{Kind: "file"},
+		{Kind: "webhook"},
+		{Kind: "nats"},
+		{Kind: "carrier-pigeon"},
+	} {
+		if _, err := buildSink(cfg); err == nil {
+			t.Errorf("buildSink(%+v) succeeded, want error", cfg)
+		}
+	}
+	entry, err := buildSink(sinkConfig{Kind: "stdout", Events: []string{"health_fail"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.wants("status_change") || !entry.wants("health_fail") {
+		t.Error("stdout sink filter not honored")
+	}
+}
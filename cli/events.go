@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// event is the stable JSON Lines schema emitted by watch/health commands in
+// --output jsonl mode. Downstream consumers (vector, fluentbit, scripts)
+// rely on these field names; extend but do not rename them.
+type event struct {
+	Timestamp string            `json:"ts"`
+	Event     string            `json:"event"`
+	Server    string            `json:"server,omitempty"`
+	Fields    map[string]string `json:"fields,omitempty"`
+}
+
+// emitEvent writes one event in the selected output mode.
+func emitEvent(output string, ev event) {
+	ev.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	if output == "jsonl" {
+		data, err := json.Marshal(ev)
+		if err != nil {
+			return
+		}
+		os.Stdout.Write(append(data, '\n'))
+		return
+	}
+	line := fmt.Sprintf("%s %-16s %s", ev.Timestamp, ev.Event, ev.Server)
+	for key, value := range ev.Fields {
+		line += fmt.Sprintf(" %s=%s", key, value)
+	}
+	fmt.Println(line)
+}
+
+var (
+	healthOutputFlag   string
+	healthWatchFlag    bool
+	healthIntervalFlag time.Duration
+)
+
+var healthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Probe server health, optionally watching continuously",
+	Long: `Probes every registered server and emits one event per result.
+With --watch, probes repeat at the configured interval until interrupted.
+Use --output jsonl for one JSON event per line with a stable schema.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		for {
+			reg, err := loadMCPRegistry(path)
+			if err != nil {
+				return err
+			}
+			results := probeAll(reg.Servers)
+			for _, srv := range reg.Servers {
+				probe := results[srv.Name]
+				fields := map[string]string{"state": probe.State.String()}
+				if probe.Detail != "" {
+					fields["detail"] = probe.Detail
+				}
+				emitEvent(healthOutputFlag, event{Event: "health", Server: srv.Name, Fields: fields})
+			}
+			if !healthWatchFlag {
+				return nil
+			}
+			time.Sleep(healthIntervalFlag)
+		}
+	},
+}
+
+var watchOutputFlag string
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch the registry for changes and emit change events",
+	Long: `Polls the registry file and emits an event whenever a server is
+added, removed, or has its enabled state or status changed. Use
+--output jsonl for machine-readable events.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		previous, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		emitEvent(watchOutputFlag, event{Event: "watch_started", Fields: map[string]string{
+			"registry": path,
+			"servers":  fmt.Sprintf("%d", len(previous.Servers)),
+		}})
+		for {
+			time.Sleep(time.Second)
+			current, err := loadMCPRegistry(path)
+			if err != nil {
+				continue // transient read during a writer's replace
+			}
+			diffRegistries(watchOutputFlag, previous, current)
+			previous = current
+		}
+	},
+}
+
+// diffRegistries emits events for differences between two registry
+// snapshots.
+func diffRegistries(output string, previous, current *MCPRegistry) {
+	prevByName := map[string]*MCPServer{}
+	for i := range previous.Servers {
+		prevByName[previous.Servers[i].Name] = &previous.Servers[i]
+	}
+	seen := map[string]bool{}
+	for i := range current.Servers {
+		srv := &current.Servers[i]
+		seen[srv.Name] = true
+		old, ok := prevByName[srv.Name]
+		if !ok {
+			emitEvent(output, event{Event: "server_added", Server: srv.Name})
+			continue
+		}
+		if old.Enabled != srv.Enabled {
+			emitEvent(output, event{Event: "server_toggled", Server: srv.Name,
+				Fields: map[string]string{"enabled": fmt.Sprintf("%v", srv.Enabled)}})
+		}
+		if old.Status != srv.Status {
+			emitEvent(output, event{Event: "status_changed", Server: srv.Name,
+				Fields: map[string]string{"from": old.Status, "to": srv.Status}})
+		}
+	}
+	for name := range prevByName {
+		if !seen[name] {
+			emitEvent(output, event{Event: "server_removed", Server: name})
+		}
+	}
+}
+
+func init() {
+	healthCmd.Flags().StringVar(&healthOutputFlag, "output", "text", "output mode: text or jsonl")
+	healthCmd.Flags().BoolVar(&healthWatchFlag, "watch", false, "probe continuously")
+	healthCmd.Flags().DurationVar(&healthIntervalFlag, "interval", 10*time.Second, "probe interval in watch mode")
+	watchCmd.Flags().StringVar(&watchOutputFlag, "output", "text", "output mode: text or jsonl")
+	rootCmd.AddCommand(healthCmd, watchCmd)
+}
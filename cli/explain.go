@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain <server>",
+	Short: "Aggregate everything known about one server for troubleshooting",
+	Long: `Collects a server's configuration, resolved launch command and
+environment, health state, recent log tail, process state, and recent
+status changes into one plain-text report suitable for pasting into an
+incident channel. Environment values with sensitive-looking names are
+redacted.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		srv := reg.findServer(args[0])
+		if srv == nil {
+			return serverNotFoundError(args[0])
+		}
+
+		fmt.Printf("=== %s ===\n", srv.Name)
+		fmt.Printf("endpoint: %s\n", srv.Endpoint)
+		fmt.Printf("status: %s  enabled: %v  category: %s  version: %s\n",
+			srv.Status, srv.Enabled, srv.Category, srv.Version)
+		if owner := ownerLine(srv); owner != "" {
+			fmt.Printf("contact: %s\n", owner)
+		}
+		if inMaintenance(srv) {
+			note := "in maintenance"
+			if srv.MaintenanceUntil != "" {
+				note += " until " + srv.MaintenanceUntil
+			}
+			fmt.Println(note)
+		}
+		for _, note := range srv.Notes {
+			fmt.Printf("note: %s (%s, %s)\n", note.Text, note.Author, note.At)
+		}
+
+		fmt.Println("\n--- launch ---")
+		launch, err := resolveLaunchCommand(srv)
+		if err != nil {
+			fmt.Printf("unresolvable: %v\n", err)
+		} else {
+			fmt.Printf("command: %s %s\n", launch.Command, strings.Join(launch.Args, " "))
+			if launch.Dir != "" {
+				fmt.Printf("workdir: %s\n", launch.Dir)
+			}
+			printResolvedEnv(srv, launch)
+		}
+
+		fmt.Println("\n--- health ---")
+		probe := probeServer(srv)
+		fmt.Printf("probe now: %s", probe.State)
+		if probe.Detail != "" {
+			fmt.Printf(" (%s)", probe.Detail)
+		}
+		fmt.Println()
+		if state, err := loadHealthState(); err == nil {
+			if rec, ok := state.Servers[srv.Name]; ok {
+				fmt.Printf("damped state: %s  failures: %d  successes: %d\n",
+					rec.State, rec.ConsecutiveFailures, rec.ConsecutiveSuccesses)
+			}
+		}
+		if srv.LastHealthCheck != "" {
+			fmt.Printf("last daemon check: %s\n", srv.LastHealthCheck)
+		}
+		if transitions, err := loadHealthTransitions(); err == nil {
+			printRecentTransitions(transitions, srv.Name, 5)
+		}
+
+		fmt.Println("\n--- process ---")
+		state, err := loadManagedState()
+		if err == nil {
+			if proc, ok := state.Servers[srv.Name]; ok {
+				if processAlive(proc.PID) {
+					fmt.Printf("managed, running: pid %d since %s\n", proc.PID, proc.StartedAt)
+					if sample, err := sampleProcess(proc.PID); err == nil {
+						fmt.Printf("usage: cpu %.1f%%  rss %.1fM  fds %d\n",
+							sample.CPUPercent, sample.RSSMegabytes, sample.OpenFDs)
+					}
+				} else {
+					fmt.Printf("managed, exited: last pid %d started %s\n", proc.PID, proc.StartedAt)
+				}
+				if len(proc.Restarts) > 0 {
+					fmt.Printf("restarts in the last %s: %d\n", crashLoopWindow, len(proc.Restarts))
+				}
+			} else {
+				fmt.Println("not managed by devgen")
+			}
+		}
+
+		if lines := tailServerLog(srv.Name, 15); len(lines) != 0 {
+			fmt.Println("\n--- log tail ---")
+			for _, line := range lines {
+				fmt.Println(line)
+			}
+		}
+		return nil
+	},
+}
+
+// printResolvedEnv shows the launch spec's environment overrides and which
+// declared environment variables are missing, redacting sensitive values.
+func printResolvedEnv(srv *MCPServer, launch *launchCommand) {
+	keys := make([]string, 0, len(launch.Env))
+	for key := range launch.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := launch.Env[key]
+		if isSensitiveKey(key) {
+			value = "[REDACTED]"
+		}
+		fmt.Printf("env: %s=%s\n", key, value)
+	}
+	for _, name := range srv.EnvironmentVars {
+		if _, ok := launch.Env[name]; ok {
+			continue
+		}
+		if envValue, set := os.LookupEnv(name); set {
+			if isSensitiveKey(name) {
+				envValue = "[REDACTED]"
+			}
+			fmt.Printf("env: %s=%s (inherited)\n", name, envValue)
+		} else {
+			fmt.Printf("env: %s is NOT SET\n", name)
+		}
+	}
+}
+
+// printRecentTransitions lists the newest damped health flips for a server.
+func printRecentTransitions(transitions []healthTransition, server string, n int) {
+	var mine []healthTransition
+	for _, tr := range transitions {
+		if tr.Server == server {
+			mine = append(mine, tr)
+		}
+	}
+	if len(mine) == 0 {
+		return
+	}
+	sort.Slice(mine, func(i, j int) bool { return mine[i].At < mine[j].At })
+	if len(mine) > n {
+		mine = mine[len(mine)-n:]
+	}
+	fmt.Println("recent transitions:")
+	for _, tr := range mine {
+		age := ""
+		if at, err := time.Parse(time.RFC3339, tr.At); err == nil {
+			age = " (" + healthAge(at) + " ago)"
+		}
+		fmt.Printf("  %s -> %s%s\n", tr.At, tr.State, age)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+}
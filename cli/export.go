@@ -0,0 +1,234 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Generate deployment artifacts from the registry",
+}
+
+var (
+	exportUserFlag   bool
+	exportOutputFlag string
+	// exportClientFilterFlag narrows every exporter to servers marked for
+	// one client through export_to or the clients tag.
+	exportClientFilterFlag string
+)
+
+var exportSystemdCmd = &cobra.Command{
+	Use:   "systemd [server...]",
+	Short: "Generate systemd unit files for machina services",
+	Long: `Writes systemd unit files for the devgen registry endpoint and for MCP
+servers so deployments survive reboots on Linux hosts. Without arguments,
+units are generated for the registry service and every enabled server with
+a resolvable launch command. With --user the units target a per-user
+systemd instance.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(exportOutputFlag, 0o755); err != nil {
+			return err
+		}
+
+		servers, err := selectExportServers(reg, args)
+		if err != nil {
+			return err
+		}
+
+		written := []string{}
+		unit, err := serveUnit()
+		if err != nil {
+			return err
+		}
+		serveFile := filepath.Join(exportOutputFlag, "devgen-serve.service")
+		if err := os.WriteFile(serveFile, []byte(unit), 0o644); err != nil {
+			return err
+		}
+		written = append(written, serveFile)
+
+		for _, srv := range servers {
+			launch, err := resolveLaunchCommand(srv)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "skipping %s: %v\n", srv.Name, err)
+				continue
+			}
+			file := filepath.Join(exportOutputFlag, "devgen-"+srv.Name+".service")
+			if err := os.WriteFile(file, []byte(serverUnit(srv, launch)), 0o644); err != nil {
+				return err
+			}
+			written = append(written, file)
+		}
+
+		for _, file := range written {
+			fmt.Println(file)
+		}
+		fmt.Printf("\nInstall with:\n  %s\n", installHint(written))
+		return nil
+	},
+}
+
+// selectExportServers picks the named servers, or all enabled ones, then
+// applies the --client profile filter.
+func selectExportServers(reg *MCPRegistry, names []string) ([]*MCPServer, error) {
+	var servers []*MCPServer
+	if len(names) > 0 {
+		for _, name := range names {
+			srv := reg.findServer(name)
+			if srv == nil {
+				return nil, serverNotFoundError(name)
+			}
+			servers = append(servers, srv)
+		}
+		return filterExportClients(servers), nil
+	}
+	// Explicitly named servers are exported regardless of status; default
+	// selection skips servers under maintenance.
+	for i := range reg.Servers {
+		if reg.Servers[i].Enabled && !inMaintenance(&reg.Servers[i]) {
+			servers = append(servers, &reg.Servers[i])
+		}
+	}
+	sort.Slice(servers, func(i, j int) bool { return servers[i].Name < servers[j].Name })
+	return filterExportClients(servers), nil
+}
+
+// filterExportClients drops servers not marked for the --client profile.
+// Explicitly named servers are filtered too: the point of the profile is
+// that experimental servers stay out of a client's config even when a
+// script lists them.
+func filterExportClients(servers []*MCPServer) []*MCPServer {
+	if exportClientFilterFlag == "" {
+		return servers
+	}
+	matched := servers[:0]
+	for _, srv := range servers {
+		if clientIncludes(srv, exportClientFilterFlag) {
+			matched = append(matched, srv)
+		}
+	}
+	return matched
+}
+
+// serveUnit renders the unit for the devgen registry HTTP endpoint.
+func serveUnit() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	root, err := findMachinaRoot()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(`[Unit]
+Description=Machina registry endpoint (devgen serve)
+After=network.target
+
+[Service]
+ExecStart=%s serve
+WorkingDirectory=%s
+Restart=on-failure
+
+[Install]
+WantedBy=%s
+`, exe, root, wantedBy()), nil
+}
+
+// serverUnit renders a unit that runs one MCP server directly, letting
+// systemd own the restart behavior its registry policy describes.
+func serverUnit(srv *MCPServer, launch *launchCommand) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[Unit]\nDescription=Machina MCP server %s\nAfter=network.target\n", srv.Name)
+	for _, root := range srv.Roots {
+		comment := ""
+		if warning := sensitiveRootWarning(root); warning != "" {
+			comment = " (WARNING: " + warning + ")"
+		}
+		fmt.Fprintf(&b, "# Filesystem root: %s%s\n", root, comment)
+	}
+	b.WriteString("\n[Service]\n")
+	fmt.Fprintf(&b, "ExecStart=%s\n", systemdCommandLine(launch))
+	if launch.Dir != "" {
+		fmt.Fprintf(&b, "WorkingDirectory=%s\n", launch.Dir)
+	}
+	envKeys := make([]string, 0, len(launch.Env))
+	for key := range launch.Env {
+		envKeys = append(envKeys, key)
+	}
+	sort.Strings(envKeys)
+	for _, key := range envKeys {
+		fmt.Fprintf(&b, "Environment=%q\n", key+"="+launch.Env[key])
+	}
+	fmt.Fprintf(&b, "Restart=%s\n", systemdRestart(srv))
+	fmt.Fprintf(&b, "\n[Install]\nWantedBy=%s\n", wantedBy())
+	return b.String()
+}
+
+// systemdCommandLine quotes arguments containing whitespace for ExecStart.
+func systemdCommandLine(launch *launchCommand) string {
+	parts := []string{launch.Command}
+	parts = append(parts, launch.Args...)
+	for i, part := range parts {
+		if strings.ContainsAny(part, " \t") {
+			parts[i] = fmt.Sprintf("%q", part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// systemdRestart maps the registry restart policy onto systemd's Restart=.
+func systemdRestart(srv *MCPServer) string {
+	switch restartPolicyFor(srv) {
+	case restartAlways:
+		return "always"
+	case restartOnFailure:
+		return "on-failure"
+	}
+	return "no"
+}
+
+func wantedBy() string {
+	if exportUserFlag {
+		return "default.target"
+	}
+	return "multi-user.target"
+}
+
+func installHint(files []string) string {
+	ctl := "sudo systemctl enable --now"
+	dir := "/etc/systemd/system"
+	if exportUserFlag {
+		ctl = "systemctl --user enable --now"
+		dir = "~/.config/systemd/user"
+	}
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = filepath.Base(file)
+	}
+	return fmt.Sprintf("cp %s %s/ && %s %s",
+		strings.Join(files, " "), dir, ctl, strings.Join(names, " "))
+}
+
+func init() {
+	exportSystemdCmd.Flags().BoolVar(&exportUserFlag, "user", false, "target a per-user systemd instance")
+	exportSystemdCmd.Flags().StringVar(&exportOutputFlag, "output", ".", "directory to write unit files into")
+
+	exportCmd.PersistentFlags().StringVar(&exportClientFilterFlag, "client", "",
+		"only export servers marked for this client (export_to field or clients tag)")
+
+	exportCmd.AddCommand(exportSystemdCmd)
+	rootCmd.AddCommand(exportCmd)
+}
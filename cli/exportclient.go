@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Client config export renders the registry as the mcpServers document
+// Claude Desktop, Cursor, and Windsurf all read; only the file location
+// differs per client. Servers carrying a clients tag are exported only
+// to the clients it lists, so an experimental server tagged
+// clients=cursor stays out of everyone else's editor config. Launch env
+// values left empty in the registry are emitted as ${KEY} placeholders
+// for the user to fill in, keeping secrets out of the registry file.
+
+// clientTargets names the supported clients and where each one expects
+// its config file.
+var clientTargets = map[string]struct {
+	display string
+	hint    string
+}{
+	"claude":   {"Claude Desktop", "~/Library/Application Support/Claude/claude_desktop_config.json"},
+	"cursor":   {"Cursor", "~/.cursor/mcp.json"},
+	"windsurf": {"Windsurf", "~/.codeium/windsurf/mcp_config.json"},
+}
+
+// clientIncludes reports whether a server should be exported to the
+// named client. The export_to registry field wins; the clients tag is
+// honored as a fallback for registries tagged before the field existed.
+// A server listing neither goes to every client, and one marked
+// cli-only matches no client at all.
+func clientIncludes(srv *MCPServer, client string) bool {
+	if len(srv.ExportTo) > 0 {
+		for _, name := range srv.ExportTo {
+			if name == client {
+				return true
+			}
+		}
+		return false
+	}
+	tag, ok := srv.Tags["clients"]
+	if !ok {
+		return true
+	}
+	for _, name := range strings.Split(tag, ",") {
+		if strings.TrimSpace(name) == client {
+			return true
+		}
+	}
+	return false
+}
+
+// clientServerEntry is one server in the mcpServers document.
+type clientServerEntry struct {
+	Command string            `json:"command"`
+	Args    []string          `json:"args,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+}
+
+// mcpServersDocument renders the shared client config shape for the
+// given servers. Servers whose launch command cannot be resolved are
+// skipped with a warning, matching the systemd exporter.
+func mcpServersDocument(servers []*MCPServer) map[string]interface{} {
+	entries := map[string]interface{}{}
+	for _, srv := range servers {
+		launch, err := resolveLaunchCommand(srv)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "skipping %s: %v\n", srv.Name, err)
+			continue
+		}
+		entry := clientServerEntry{Command: launch.Command, Args: launch.Args}
+		if len(launch.Env) > 0 {
+			entry.Env = map[string]string{}
+			for key, value := range launch.Env {
+				if value == "" {
+					value = "${" + key + "}"
+				}
+				entry.Env[key] = value
+			}
+		}
+		entries[srv.Name] = entry
+	}
+	return map[string]interface{}{"mcpServers": entries}
+}
+
+var exportClientOutputFlag string
+
+// newExportClientCmd builds one per-client export subcommand; the three
+// clients differ only in name and target path.
+func newExportClientCmd(client string) *cobra.Command {
+	target := clientTargets[client]
+	return &cobra.Command{
+		Use:   client + " [server...]",
+		Short: fmt.Sprintf("Generate the %s MCP config", target.display),
+		Long: fmt.Sprintf(`Renders the enabled servers (or the named ones) as the mcpServers
+document %s reads, usually from %s.
+Servers with a clients tag are included only when it lists %q. With
+--output - the document goes to stdout for piping into jq or an existing
+config merge.`, target.display, target.hint, client),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := registryPath()
+			if err != nil {
+				return err
+			}
+			reg, err := loadMCPRegistry(path)
+			if err != nil {
+				return err
+			}
+			servers, err := selectExportServers(reg, args)
+			if err != nil {
+				return err
+			}
+			matched := servers[:0]
+			for _, srv := range servers {
+				if clientIncludes(srv, client) {
+					matched = append(matched, srv)
+				}
+			}
+			doc := mcpServersDocument(matched)
+			data, err := json.MarshalIndent(doc, "", "  ")
+			if err != nil {
+				return err
+			}
+			data = append(data, '\n')
+			if exportClientOutputFlag == "-" {
+				_, err := cmd.OutOrStdout().Write(data)
+				return err
+			}
+			if err := os.WriteFile(exportClientOutputFlag, data, 0o644); err != nil {
+				return err
+			}
+			fmt.Printf("Wrote %s (%s reads it from %s)\n", exportClientOutputFlag, target.display, target.hint)
+			return nil
+		},
+	}
+}
+
+func init() {
+	clients := make([]string, 0, len(clientTargets))
+	for client := range clientTargets {
+		clients = append(clients, client)
+	}
+	sort.Strings(clients)
+	for _, client := range clients {
+		cmd := newExportClientCmd(client)
+		cmd.Flags().StringVar(&exportClientOutputFlag, "output", "-", "file to write, or - for stdout")
+		exportCmd.AddCommand(cmd)
+	}
+}
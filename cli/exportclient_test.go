@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestClientIncludes(t *testing.T) {
+	untagged := &MCPServer{Name: "context7"}
+	tagged := &MCPServer{Name: "experimental", Tags: map[string]string{"clients": "cursor, windsurf"}}
+
+	for _, client := range []string{"claude", "cursor", "windsurf"} {
+		if !clientIncludes(untagged, client) {
+			t.Errorf("untagged server excluded from %s", client)
+		}
+	}
+	if clientIncludes(tagged, "claude") {
+		t.Error("clients=cursor,windsurf server leaked into claude export")
+	}
+	if !clientIncludes(tagged, "cursor") || !clientIncludes(tagged, "windsurf") {
+		t.Error("tagged server excluded from a listed client")
+	}
+
+	profiled := &MCPServer{Name: "profiled", ExportTo: []string{"claude"},
+		Tags: map[string]string{"clients": "cursor"}}
+	if !clientIncludes(profiled, "claude") || clientIncludes(profiled, "cursor") {
+		t.Error("export_to did not take precedence over the clients tag")
+	}
+	cliOnly := &MCPServer{Name: "internal", ExportTo: []string{"cli-only"}}
+	for _, client := range []string{"claude", "cursor", "windsurf"} {
+		if clientIncludes(cliOnly, client) {
+			t.Errorf("cli-only server leaked into %s", client)
+		}
+	}
+}
+
+func TestMCPServersDocumentPlaceholders(t *testing.T) {
+	srv := &MCPServer{
+		Name:     "surrealdb",
+		Endpoint: "stdio://x",
+		Launch: &LaunchSpec{
+			Command: "surrealdb-mcp",
+			Args:    []string{"--ns", "ptolemies"},
+			Env:     map[string]string{"SURREALDB_URL": "ws://localhost:8000/rpc", "SURREALDB_PASSWORD": ""},
+		},
+	}
+	doc := mcpServersDocument([]*MCPServer{srv})
+	entries := doc["mcpServers"].(map[string]interface{})
+	entry, ok := entries["surrealdb"].(clientServerEntry)
+	if !ok {
+		t.Fatalf("entry = %T %v", entries["surrealdb"], entries["surrealdb"])
+	}
+	if entry.Command != "surrealdb-mcp" || len(entry.Args) != 2 {
+		t.Errorf("entry command/args = %q %v", entry.Command, entry.Args)
+	}
+	if entry.Env["SURREALDB_URL"] != "ws://localhost:8000/rpc" {
+		t.Errorf("non-empty env value rewritten: %q", entry.Env["SURREALDB_URL"])
+	}
+	if entry.Env["SURREALDB_PASSWORD"] != "${SURREALDB_PASSWORD}" {
+		t.Errorf("empty env value = %q, want placeholder", entry.Env["SURREALDB_PASSWORD"])
+	}
+}
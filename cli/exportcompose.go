@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Compose export turns the registry into a docker-compose.yaml so the
+// active server set can be brought up on another machine with one
+// command. Only servers whose launch spec names a container image become
+// services; the rest are launched from source and skipped with a note.
+// Launch env goes into one env file per service, with empty values left
+// as KEY= lines for the operator to fill, keeping secrets out of the
+// compose file. cmd: health checks run inside the container and map onto
+// compose healthchecks; tcp/http/mcp checks are probed from outside by
+// devgen and are not translated.
+
+// composeHealthcheck is the compose healthcheck block.
+type composeHealthcheck struct {
+	Test    []string `yaml:"test"`
+	Timeout string   `yaml:"timeout,omitempty"`
+	Retries int      `yaml:"retries,omitempty"`
+}
+
+// composeService is one service entry in the compose document.
+type composeService struct {
+	Image       string              `yaml:"image"`
+	Command     []string            `yaml:"command,omitempty"`
+	WorkingDir  string              `yaml:"working_dir,omitempty"`
+	EnvFile     []string            `yaml:"env_file,omitempty"`
+	Ports       []string            `yaml:"ports,omitempty"`
+	Volumes     []string            `yaml:"volumes,omitempty"`
+	Restart     string              `yaml:"restart,omitempty"`
+	Healthcheck *composeHealthcheck `yaml:"healthcheck,omitempty"`
+}
+
+type composeDocument struct {
+	Services map[string]composeService `yaml:"services"`
+}
+
+// composeRestart maps the registry restart policy onto compose's
+// restart values.
+func composeRestart(srv *MCPServer) string {
+	switch restartPolicyFor(srv) {
+	case restartAlways:
+		return "always"
+	case restartOnFailure:
+		return "on-failure"
+	}
+	return "no"
+}
+
+// composeServerService renders one container server, returning the env
+// file content to write beside the compose file (empty when the server
+// has no launch env).
+func composeServerService(srv *MCPServer) (composeService, string) {
+	launch := srv.Launch
+	service := composeService{
+		Image:   launch.Image,
+		Restart: composeRestart(srv),
+	}
+	if launch.Command != "" {
+		service.Command = append([]string{launch.Command}, launch.Args...)
+	}
+	if spec, err := parseHealthCheck(srv.HealthCheck); err == nil && spec.Kind == "cmd" {
+		service.Healthcheck = &composeHealthcheck{
+			Test:    []string{"CMD-SHELL", spec.Target},
+			Timeout: spec.Timeout.String(),
+			Retries: 3,
+		}
+	}
+	if len(launch.Env) == 0 {
+		return service, ""
+	}
+	service.EnvFile = []string{srv.Name + ".env"}
+	keys := make([]string, 0, len(launch.Env))
+	for key := range launch.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var envFile strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&envFile, "%s=%s\n", key, launch.Env[key])
+	}
+	return service, envFile.String()
+}
+
+// composeRegistryService renders the devgen serve endpoint, mounting the
+// compose directory as the machina root so every service shares one
+// registry file.
+func composeRegistryService(image string) composeService {
+	return composeService{
+		Image:      image,
+		Command:    []string{"serve", "--addr", ":8642"},
+		WorkingDir: "/machina",
+		Volumes:    []string{"./:/machina"},
+		Ports:      []string{"8642:8642"},
+		Restart:    "on-failure",
+	}
+}
+
+var (
+	composeOutputFlag      string
+	composeDevgenImageFlag string
+)
+
+var exportComposeCmd = &cobra.Command{
+	Use:   "compose [server...]",
+	Short: "Generate a docker-compose.yaml for the active server set",
+	Long: `Writes docker-compose.yaml plus one env file per service into the
+output directory, covering every enabled server whose launch spec names a
+container image. With --devgen-image the registry endpoint (devgen serve)
+is included as a service publishing port 8642, with the output directory
+mounted as the machina root. The stdio proxy is per-connection rather
+than a network service, so it has no compose entry; clients run
+'devgen proxy' against the mounted registry instead.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		servers, err := selectExportServers(reg, args)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(composeOutputFlag, 0o755); err != nil {
+			return err
+		}
+
+		doc := composeDocument{Services: map[string]composeService{}}
+		written := []string{}
+		for _, srv := range servers {
+			if srv.Launch == nil || srv.Launch.Image == "" {
+				fmt.Fprintf(os.Stderr, "skipping %s: no container image in launch spec\n", srv.Name)
+				continue
+			}
+			service, envFile := composeServerService(srv)
+			doc.Services[srv.Name] = service
+			if envFile != "" {
+				file := filepath.Join(composeOutputFlag, srv.Name+".env")
+				if err := os.WriteFile(file, []byte(envFile), 0o600); err != nil {
+					return err
+				}
+				written = append(written, file)
+			}
+		}
+		if composeDevgenImageFlag != "" {
+			doc.Services["registry"] = composeRegistryService(composeDevgenImageFlag)
+		}
+		if len(doc.Services) == 0 {
+			return fmt.Errorf("no servers with container images (set launch.image, or pass --devgen-image for the registry service)")
+		}
+
+		data, err := yaml.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		file := filepath.Join(composeOutputFlag, "docker-compose.yaml")
+		if err := os.WriteFile(file, data, 0o644); err != nil {
+			return err
+		}
+		written = append(written, file)
+
+		for _, file := range written {
+			fmt.Println(file)
+		}
+		fmt.Printf("\nBring the stack up with:\n  docker compose -f %s up -d\n", file)
+		return nil
+	},
+}
+
+func init() {
+	exportComposeCmd.Flags().StringVar(&composeOutputFlag, "output", ".", "directory to write the compose file and env files into")
+	exportComposeCmd.Flags().StringVar(&composeDevgenImageFlag, "devgen-image", "", "container image providing the devgen binary for the registry service")
+	exportCmd.AddCommand(exportComposeCmd)
+}
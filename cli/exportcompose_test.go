@@ -0,0 +1,66 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestComposeServerService(t *testing.T) {
+	srv := &MCPServer{
+		Name:        "surrealdb",
+		HealthCheck: "cmd:surreal isready",
+		Restart:     &RestartPolicy{Policy: "always"},
+		Launch: &LaunchSpec{
+			Image:   "surrealdb/surrealdb:v2",
+			Command: "start",
+			Args:    []string{"--user", "root"},
+			Env:     map[string]string{"SURREAL_PASS": "", "SURREAL_USER": "root"},
+		},
+	}
+	service, envFile := composeServerService(srv)
+	if service.Image != "surrealdb/surrealdb:v2" {
+		t.Errorf("image = %q", service.Image)
+	}
+	if len(service.Command) != 3 || service.Command[0] != "start" {
+		t.Errorf("command = %v", service.Command)
+	}
+	if service.Restart != "always" {
+		t.Errorf("restart = %q", service.Restart)
+	}
+	if service.Healthcheck == nil || service.Healthcheck.Test[1] != "surreal isready" {
+		t.Errorf("healthcheck = %+v", service.Healthcheck)
+	}
+	if len(service.EnvFile) != 1 || service.EnvFile[0] != "surrealdb.env" {
+		t.Errorf("env_file = %v", service.EnvFile)
+	}
+	// Sorted keys, empty values left for the operator.
+	if envFile != "SURREAL_PASS=\nSURREAL_USER=root\n" {
+		t.Errorf("env file = %q", envFile)
+	}
+
+	// The document must be valid YAML with the service under services.
+	data, err := yaml.Marshal(composeDocument{Services: map[string]composeService{"surrealdb": service}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "image: surrealdb/surrealdb:v2") {
+		t.Errorf("compose yaml missing image:\n%s", data)
+	}
+}
+
+func TestComposeServerServiceSkipsExternalChecks(t *testing.T) {
+	srv := &MCPServer{
+		Name:        "fs",
+		HealthCheck: "http://localhost:9000/health",
+		Launch:      &LaunchSpec{Image: "machina/fs:1"},
+	}
+	service, envFile := composeServerService(srv)
+	if service.Healthcheck != nil {
+		t.Errorf("http check translated to compose healthcheck: %+v", service.Healthcheck)
+	}
+	if envFile != "" || service.EnvFile != nil {
+		t.Errorf("env file emitted without launch env: %q %v", envFile, service.EnvFile)
+	}
+}
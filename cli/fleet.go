@@ -0,0 +1,262 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/spf13/cobra"
+)
+
+// Fleet mode links multiple devgen daemons through a shared NATS bus:
+// each daemon publishes a snapshot of its server states after every
+// supervise sweep, and 'devgen fleet' merges the latest snapshot from
+// every host into one view. Configure it with the fleet config block;
+// without a bus URL fleet mode is off and nothing is published.
+
+// fleetConfig is the config block for the shared state bus.
+type fleetConfig struct {
+	// Bus is the NATS server URL; empty disables fleet mode.
+	Bus string `json:"bus,omitempty"`
+	// Subject is the NATS subject snapshots are published on
+	// (default devgen.fleet).
+	Subject string `json:"subject,omitempty"`
+	// Host overrides the hostname reported in snapshots.
+	Host string `json:"host,omitempty"`
+	// Hosts are the remote machines fleet commands fan out to over SSH;
+	// see fleetssh.go.
+	Hosts []string `json:"hosts,omitempty"`
+}
+
+// defaultFleetSubject carries fleet snapshots unless overridden.
+const defaultFleetSubject = "devgen.fleet"
+
+// fleetSnapshot is one host's published server state.
+type fleetSnapshot struct {
+	Host    string             `json:"host"`
+	At      string             `json:"at"`
+	Servers []fleetServerState `json:"servers"`
+}
+
+// fleetServerState is the per-server slice of a snapshot: just enough to
+// render a merged fleet table without shipping whole registry entries.
+type fleetServerState struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Enabled bool   `json:"enabled"`
+	Health  string `json:"health,omitempty"`
+}
+
+// fleetHostName resolves the host label for published snapshots.
+func fleetHostName(cfg fleetConfig) string {
+	if cfg.Host != "" {
+		return cfg.Host
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		return "unknown"
+	}
+	return host
+}
+
+func fleetSubject(cfg fleetConfig) string {
+	if cfg.Subject != "" {
+		return cfg.Subject
+	}
+	return defaultFleetSubject
+}
+
+// buildFleetSnapshot assembles this host's snapshot from the registry and
+// the damped health state.
+func buildFleetSnapshot(regPath, host string) (fleetSnapshot, error) {
+	reg, err := loadMCPRegistry(regPath)
+	if err != nil {
+		return fleetSnapshot{}, err
+	}
+	health, err := loadHealthState()
+	if err != nil {
+		return fleetSnapshot{}, err
+	}
+	snapshot := fleetSnapshot{
+		Host: host,
+		At:   time.Now().UTC().Format(time.RFC3339),
+	}
+	for _, srv := range reg.Servers {
+		snapshot.Servers = append(snapshot.Servers, fleetServerState{
+			Name:    srv.Name,
+			Status:  srv.Status,
+			Enabled: srv.Enabled,
+			Health:  health.Servers[srv.Name].State,
+		})
+	}
+	return snapshot, nil
+}
+
+// fleetPublisher pushes snapshots onto the bus from the daemon loop.
+type fleetPublisher struct {
+	conn    *nats.Conn
+	subject string
+	host    string
+}
+
+// newFleetPublisher connects to the configured bus, returning nil when
+// fleet mode is not configured.
+func newFleetPublisher(cfg fleetConfig) (*fleetPublisher, error) {
+	if cfg.Bus == "" {
+		return nil, nil
+	}
+	conn, err := nats.Connect(cfg.Bus, nats.Name("devgen-daemon"))
+	if err != nil {
+		return nil, fmt.Errorf("connecting to fleet bus %s: %w", cfg.Bus, err)
+	}
+	return &fleetPublisher{conn: conn, subject: fleetSubject(cfg), host: fleetHostName(cfg)}, nil
+}
+
+func (p *fleetPublisher) publish(regPath string) error {
+	snapshot, err := buildFleetSnapshot(regPath, p.host)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return p.conn.Publish(p.subject, payload)
+}
+
+func (p *fleetPublisher) close() {
+	p.conn.Close()
+}
+
+// collectFleetSnapshots listens on the bus for the given window and
+// returns the latest snapshot per host.
+func collectFleetSnapshots(cfg fleetConfig, window time.Duration) (map[string]fleetSnapshot, error) {
+	conn, err := nats.Connect(cfg.Bus, nats.Name("devgen-fleet"))
+	if err != nil {
+		return nil, fmt.Errorf("connecting to fleet bus %s: %w", cfg.Bus, err)
+	}
+	defer conn.Close()
+	ch := make(chan *nats.Msg, 64)
+	sub, err := conn.ChanSubscribe(fleetSubject(cfg), ch)
+	if err != nil {
+		return nil, err
+	}
+	defer sub.Unsubscribe()
+
+	hosts := map[string]fleetSnapshot{}
+	deadline := time.After(window)
+	for {
+		select {
+		case msg := <-ch:
+			var snapshot fleetSnapshot
+			if err := json.Unmarshal(msg.Data, &snapshot); err != nil {
+				debugf("fleet: discarding malformed snapshot: %v", err)
+				continue
+			}
+			hosts[snapshot.Host] = snapshot
+		case <-deadline:
+			return hosts, nil
+		}
+	}
+}
+
+var (
+	fleetWaitFlag time.Duration
+	fleetJSONFlag bool
+)
+
+var fleetCmd = &cobra.Command{
+	Use:   "fleet",
+	Short: "Show merged server state from every host on the fleet bus",
+	Long: `Listens on the configured NATS bus for the snapshots each devgen
+daemon publishes after its supervise sweep and prints a merged table with
+one row per host and server. The local registry is always included, so
+the view is useful even before remote daemons have published. Daemons
+publish every 15 seconds; --wait must cover at least one publish interval
+to catch every host.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if cfg.Fleet.Bus == "" {
+			return fmt.Errorf("fleet bus not configured: set fleet.bus to a NATS URL in the devgen config")
+		}
+		hosts, err := collectFleetSnapshots(cfg.Fleet, fleetWaitFlag)
+		if err != nil {
+			return err
+		}
+		if local, err := localFleetSnapshot(cfg.Fleet); err == nil {
+			// A snapshot received from this host's daemon is fresher than
+			// one we build now only in clock terms; prefer the local read
+			// so the view reflects mutations made since the last sweep.
+			hosts[local.Host] = local
+		} else {
+			debugf("fleet: local snapshot: %v", err)
+		}
+		if len(hosts) == 0 {
+			return fmt.Errorf("no fleet snapshots received within %s", fleetWaitFlag)
+		}
+		return renderFleet(hosts)
+	},
+}
+
+// localFleetSnapshot builds this host's snapshot straight from disk, so
+// the fleet view works without a local daemon running.
+func localFleetSnapshot(cfg fleetConfig) (fleetSnapshot, error) {
+	path, err := registryPath()
+	if err != nil {
+		return fleetSnapshot{}, err
+	}
+	return buildFleetSnapshot(path, fleetHostName(cfg))
+}
+
+func renderFleet(hosts map[string]fleetSnapshot) error {
+	if fleetJSONFlag {
+		ordered := make([]fleetSnapshot, 0, len(hosts))
+		for _, snapshot := range hosts {
+			ordered = append(ordered, snapshot)
+		}
+		sort.Slice(ordered, func(i, j int) bool { return ordered[i].Host < ordered[j].Host })
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(ordered)
+	}
+
+	names := make([]string, 0, len(hosts))
+	for host := range hosts {
+		names = append(names, host)
+	}
+	sort.Strings(names)
+	fmt.Printf("%-16s %-24s %-18s %-10s %-8s %s\n", "HOST", "SERVER", "STATUS", "HEALTH", "ENABLED", "AGE")
+	for _, host := range names {
+		snapshot := hosts[host]
+		age := "-"
+		if at, err := time.Parse(time.RFC3339, snapshot.At); err == nil {
+			age = healthAge(at)
+		}
+		servers := append([]fleetServerState(nil), snapshot.Servers...)
+		sort.Slice(servers, func(i, j int) bool { return servers[i].Name < servers[j].Name })
+		for _, srv := range servers {
+			enabled := "no"
+			if srv.Enabled {
+				enabled = "yes"
+			}
+			health := srv.Health
+			if health == "" {
+				health = "-"
+			}
+			fmt.Printf("%-16s %-24s %-18s %-10s %-8s %s\n", host, srv.Name, srv.Status, health, enabled, age)
+		}
+	}
+	return nil
+}
+
+func init() {
+	fleetCmd.Flags().DurationVar(&fleetWaitFlag, "wait", 3*time.Second, "how long to listen for host snapshots")
+	fleetCmd.Flags().BoolVar(&fleetJSONFlag, "json", false, "emit the merged snapshots as JSON")
+	rootCmd.AddCommand(fleetCmd)
+}
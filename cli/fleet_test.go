@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFleetConfigDefaults(t *testing.T) {
+	if got := fleetSubject(fleetConfig{}); got != defaultFleetSubject {
+		t.Errorf("default subject = %q", got)
+	}
+	if got := fleetSubject(fleetConfig{Subject: "ops.machina"}); got != "ops.machina" {
+		t.Errorf("override subject = %q", got)
+	}
+	if got := fleetHostName(fleetConfig{Host: "web1"}); got != "web1" {
+		t.Errorf("override host = %q", got)
+	}
+	if got := fleetHostName(fleetConfig{}); got == "" {
+		t.Error("default host is empty")
+	}
+}
+
+func TestDashboardFleetHostColumn(t *testing.T) {
+	model := dashboardModel{
+		servers: []MCPServer{
+			{Name: "context7", Status: "active", Enabled: true},
+			{Name: "bayes", Status: "active", Enabled: true},
+		},
+		collapsed: map[string]bool{},
+		keys:      keymapFromConfig(keymapConfig{}),
+		styles:    newDashboardStyles(resolveTheme(themeConfig{})),
+		fleetHosts: map[string]fleetSnapshot{
+			"web2": {Host: "web2", Servers: []fleetServerState{{Name: "context7"}}},
+			"web1": {Host: "web1", Servers: []fleetServerState{{Name: "context7"}}},
+		},
+	}
+	model.rebuildItems()
+
+	if got := model.fleetHostsFor("context7"); got != "web1,web2" {
+		t.Errorf("fleetHostsFor(context7) = %q, want sorted host list", got)
+	}
+	if got := model.fleetHostsFor("bayes"); got != "-" {
+		t.Errorf("fleetHostsFor(bayes) = %q, want -", got)
+	}
+
+	view := model.View()
+	if !strings.Contains(view, "HOST") {
+		t.Error("fleet view is missing the HOST column header")
+	}
+	if !strings.Contains(view, "web1,web2") {
+		t.Error("fleet view is missing the host list for context7")
+	}
+
+	// Without fleet snapshots the column disappears entirely.
+	model.fleetHosts = nil
+	if strings.Contains(model.View(), "HOST") {
+		t.Error("HOST column rendered without fleet snapshots")
+	}
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+// SSH fan-out complements the fleet bus for small multi-machine setups:
+// hosts registered with 'fleet add-host' are reached by running the
+// system ssh client against the devgen binary installed there, so
+// existing ssh configs, agents, and jump hosts all apply. The bus gives
+// a passive merged view; these subcommands actively query and control
+// remote hosts.
+
+// fleetSSHTimeout bounds one remote devgen invocation.
+const fleetSSHTimeout = 20 * time.Second
+
+// fleetHosts returns the configured remote hosts.
+func fleetHosts(cfg fleetConfig) []string {
+	return cfg.Hosts
+}
+
+// fleetSSHRun executes devgen on a remote host and returns its stdout.
+// BatchMode keeps a missing key from hanging the fan-out on a password
+// prompt.
+func fleetSSHRun(host string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), fleetSSHTimeout)
+	defer cancel()
+	sshArgs := append([]string{"-o", "BatchMode=yes", host, "devgen"}, args...)
+	out, err := exec.CommandContext(ctx, "ssh", sshArgs...).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("%s: %s", host, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("%s: %w", host, err)
+	}
+	return out, nil
+}
+
+// fleetStatusFanOut queries 'devgen status --json' on every configured
+// host concurrently. Hosts that fail are reported alongside the results
+// so one dead box doesn't hide the rest of the fleet.
+func fleetStatusFanOut(hosts []string) (map[string]statusSummary, map[string]error) {
+	var mu sync.Mutex
+	summaries := map[string]statusSummary{}
+	failures := map[string]error{}
+	var group errgroup.Group
+	for _, host := range hosts {
+		host := host
+		group.Go(func() error {
+			out, err := fleetSSHRun(host, "status", "--json")
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failures[host] = err
+				return nil
+			}
+			var summary statusSummary
+			if err := json.Unmarshal(out, &summary); err != nil {
+				failures[host] = fmt.Errorf("parsing status from %s: %w", host, err)
+				return nil
+			}
+			summaries[host] = summary
+			return nil
+		})
+	}
+	group.Wait()
+	return summaries, failures
+}
+
+func requireFleetHosts() ([]string, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	hosts := fleetHosts(cfg.Fleet)
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("no fleet hosts configured: run 'devgen fleet add-host user@host' first")
+	}
+	return hosts, nil
+}
+
+var fleetAddHostCmd = &cobra.Command{
+	Use:   "add-host <user@host>",
+	Short: "Register a remote host for fleet commands",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		host := strings.TrimSpace(args[0])
+		if host == "" || strings.ContainsAny(host, " \t") {
+			return fmt.Errorf("invalid host %q: want user@host or an ssh config alias", args[0])
+		}
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		for _, existing := range cfg.Fleet.Hosts {
+			if existing == host {
+				return fmt.Errorf("host already registered: %s", host)
+			}
+		}
+		if err := mutateFleetHosts(append(cfg.Fleet.Hosts, host)); err != nil {
+			return err
+		}
+		fmt.Printf("Added fleet host %s\n", host)
+		return nil
+	},
+}
+
+var fleetRemoveHostCmd = &cobra.Command{
+	Use:   "remove-host <user@host>",
+	Short: "Remove a remote host from the fleet",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		kept := make([]string, 0, len(cfg.Fleet.Hosts))
+		for _, host := range cfg.Fleet.Hosts {
+			if host != args[0] {
+				kept = append(kept, host)
+			}
+		}
+		if len(kept) == len(cfg.Fleet.Hosts) {
+			return fmt.Errorf("host not registered: %s", args[0])
+		}
+		if err := mutateFleetHosts(kept); err != nil {
+			return err
+		}
+		fmt.Printf("Removed fleet host %s\n", args[0])
+		return nil
+	},
+}
+
+// mutateFleetHosts rewrites fleet.hosts through the raw config document,
+// the same path 'devgen config set' uses, so unknown keys survive.
+func mutateFleetHosts(hosts []string) error {
+	raw, err := loadRawConfig()
+	if err != nil {
+		return err
+	}
+	values := make([]interface{}, len(hosts))
+	for i, host := range hosts {
+		values[i] = host
+	}
+	if err := setConfigKey(raw, "fleet.hosts", values); err != nil {
+		return err
+	}
+	return saveRawConfig(raw)
+}
+
+var fleetHostsCmd = &cobra.Command{
+	Use:   "hosts",
+	Short: "List registered fleet hosts",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if len(cfg.Fleet.Hosts) == 0 {
+			fmt.Println("no fleet hosts configured")
+			return nil
+		}
+		for _, host := range cfg.Fleet.Hosts {
+			fmt.Println(host)
+		}
+		return nil
+	},
+}
+
+var fleetListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List servers on every fleet host",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		hosts, err := requireFleetHosts()
+		if err != nil {
+			return err
+		}
+		summaries, failures := fleetStatusFanOut(hosts)
+		fmt.Printf("%-16s %-24s %-18s %s\n", "HOST", "SERVER", "STATUS", "ENABLED")
+		for _, host := range sortedHostKeys(summaries) {
+			for _, st := range summaries[host].Servers {
+				enabled := "no"
+				if st.Enabled {
+					enabled = "yes"
+				}
+				fmt.Printf("%-16s %-24s %-18s %s\n", host, st.Name, st.Status, enabled)
+			}
+		}
+		return reportFleetFailures(failures)
+	},
+}
+
+var fleetHealthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Probe server health on every fleet host",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		hosts, err := requireFleetHosts()
+		if err != nil {
+			return err
+		}
+		summaries, failures := fleetStatusFanOut(hosts)
+		fmt.Printf("%-16s %-24s %-12s %s\n", "HOST", "SERVER", "HEALTH", "DETAIL")
+		for _, host := range sortedHostKeys(summaries) {
+			for _, st := range summaries[host].Servers {
+				fmt.Printf("%-16s %-24s %-12s %s\n", host, st.Name, st.Health, st.Detail)
+			}
+		}
+		return reportFleetFailures(failures)
+	},
+}
+
+// fleetControlCmd builds the start/stop fan-out commands, which run the
+// same single-server command on every host and report per-host outcomes.
+func fleetControlCmd(verb, short string) *cobra.Command {
+	return &cobra.Command{
+		Use:   verb + " <server>",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			hosts, err := requireFleetHosts()
+			if err != nil {
+				return err
+			}
+			var mu sync.Mutex
+			failures := map[string]error{}
+			var group errgroup.Group
+			for _, host := range hosts {
+				host := host
+				group.Go(func() error {
+					out, err := fleetSSHRun(host, verb, args[0])
+					mu.Lock()
+					defer mu.Unlock()
+					if err != nil {
+						failures[host] = err
+						return nil
+					}
+					fmt.Printf("%-16s %s\n", host, strings.TrimSpace(string(out)))
+					return nil
+				})
+			}
+			group.Wait()
+			return reportFleetFailures(failures)
+		},
+	}
+}
+
+func sortedHostKeys(summaries map[string]statusSummary) []string {
+	hosts := make([]string, 0, len(summaries))
+	for host := range summaries {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// reportFleetFailures prints per-host errors and returns non-zero when
+// any host could not be reached, so scripts notice partial results.
+func reportFleetFailures(failures map[string]error) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	hosts := make([]string, 0, len(failures))
+	for host := range failures {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	for _, host := range hosts {
+		fmt.Printf("FAIL: %v\n", failures[host])
+	}
+	return fmt.Errorf("%d fleet host(s) unreachable", len(failures))
+}
+
+func init() {
+	fleetCmd.AddCommand(fleetAddHostCmd, fleetRemoveHostCmd, fleetHostsCmd,
+		fleetListCmd, fleetHealthCmd,
+		fleetControlCmd("start", "Start a server on every fleet host"),
+		fleetControlCmd("stop", "Stop a server on every fleet host"))
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestMutateFleetHostsRoundTrip(t *testing.T) {
+	t.Setenv("DEVGEN_CONFIG", filepath.Join(t.TempDir(), "config.json"))
+
+	if err := mutateFleetHosts([]string{"ops@web1", "ops@web2"}); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Fleet.Hosts) != 2 || cfg.Fleet.Hosts[0] != "ops@web1" || cfg.Fleet.Hosts[1] != "ops@web2" {
+		t.Errorf("hosts after add = %v", cfg.Fleet.Hosts)
+	}
+
+	if err := mutateFleetHosts([]string{"ops@web2"}); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = loadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Fleet.Hosts) != 1 || cfg.Fleet.Hosts[0] != "ops@web2" {
+		t.Errorf("hosts after remove = %v", cfg.Fleet.Hosts)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// gitRegistryEnabled reports whether registry mutations should be
+// committed to git (the git_registry config key).
+func gitRegistryEnabled() bool {
+	cfg, err := loadConfig()
+	return err == nil && cfg.GitRegistry
+}
+
+// commitRegistryChange records a registry write as a git commit in the
+// registry's directory, initializing the repository on first use. The
+// commit message names the invoking command and actor so teams get review
+// and rollback from normal git tooling. Failures are reported but do not
+// undo the write itself.
+func commitRegistryChange(path string) {
+	if !gitRegistryEnabled() {
+		return
+	}
+	dir := filepath.Dir(path)
+	if err := runGit(dir, "rev-parse", "--git-dir"); err != nil {
+		if err := runGit(dir, "init", "--quiet"); err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: git registry: init failed: %v\n", err)
+			return
+		}
+	}
+	if err := runGit(dir, "add", filepath.Base(path)); err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: git registry: add failed: %v\n", err)
+		return
+	}
+	message := fmt.Sprintf("devgen %s (by %s)", strings.Join(os.Args[1:], " "), currentActor())
+	if err := runGit(dir, "commit", "--quiet", "--no-verify", "-m", message,
+		"--", filepath.Base(path)); err != nil {
+		// A no-op write (nothing staged) is not worth a warning.
+		if strings.Contains(err.Error(), "nothing to commit") {
+			debugf("git registry: commit skipped: %v", err)
+			return
+		}
+		fmt.Fprintf(os.Stderr, "WARNING: git registry: commit failed: %v\n", err)
+	}
+}
+
+func currentActor() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return "unknown"
+}
+
+func runGit(dir string, args ...string) error {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git %s: %v: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+var historyLimitFlag int
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the registry's git change log",
+	Long: `Shows the commit log of registry mutations. Requires git-backed
+registry mode ('devgen config set git_registry true'), which commits every
+registry write with the command and actor that made it.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		if !gitRegistryEnabled() {
+			return fmt.Errorf("git-backed registry mode is off: enable it with 'devgen config set git_registry true'")
+		}
+		dir := filepath.Dir(path)
+		git := exec.Command("git", "-C", dir, "log", "--oneline", "--no-decorate",
+			fmt.Sprintf("-%d", historyLimitFlag), "--", filepath.Base(path))
+		git.Stdout = os.Stdout
+		git.Stderr = os.Stderr
+		if err := git.Run(); err != nil {
+			return fmt.Errorf("no registry history yet: %w", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	historyCmd.Flags().IntVarP(&historyLimitFlag, "limit", "n", 20, "number of commits to show")
+	rootCmd.AddCommand(historyCmd)
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// healthState is the live probe verdict, independent of the status string
+// stored in the registry.
+type healthState int
+
+const (
+	healthUnknown healthState = iota
+	healthHealthy
+	healthDegraded
+	healthUnreachable
+)
+
+func (s healthState) String() string {
+	switch s {
+	case healthHealthy:
+		return "healthy"
+	case healthDegraded:
+		return "degraded"
+	case healthUnreachable:
+		return "unreachable"
+	}
+	return "unknown"
+}
+
+// probeResult is the outcome of one health probe.
+type probeResult struct {
+	State     healthState
+	Detail    string
+	CheckedAt time.Time
+}
+
+// probeServer checks one server's health without blocking the caller for
+// long: HTTP servers get a GET /health with a short timeout; stdio servers
+// get a static check of script presence and required environment variables.
+func probeServer(srv *MCPServer) probeResult {
+	result := probeResult{CheckedAt: time.Now()}
+
+	// A typed health_check overrides the scheme-derived default probe.
+	if srv.HealthCheck != "" {
+		spec, err := parseHealthCheck(srv.HealthCheck)
+		if err != nil {
+			result.State = healthUnknown
+			result.Detail = err.Error()
+			return result
+		}
+		return runHealthCheck(srv, spec)
+	}
+
+	if strings.HasPrefix(srv.Endpoint, "http://") || strings.HasPrefix(srv.Endpoint, "https://") {
+		target := strings.TrimSuffix(srv.Endpoint, "/") + "/health"
+		resp, err := outboundRetryPolicy().doHTTP(outboundHTTPClient(2*time.Second), func() (*http.Request, error) {
+			return http.NewRequest(http.MethodGet, target, nil)
+		})
+		if err != nil {
+			result.State = healthUnreachable
+			result.Detail = err.Error()
+			return result
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			result.State = healthHealthy
+		} else {
+			result.State = healthDegraded
+			result.Detail = fmt.Sprintf("health endpoint returned %d", resp.StatusCode)
+		}
+		return result
+	}
+
+	if strings.HasPrefix(srv.Endpoint, "stdio://") || srv.Launch != nil {
+		if _, err := resolveLaunchCommand(srv); err != nil {
+			result.State = healthUnreachable
+			result.Detail = err.Error()
+			return result
+		}
+		var missing []string
+		for _, name := range srv.EnvironmentVars {
+			if os.Getenv(name) == "" {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			result.State = healthDegraded
+			result.Detail = fmt.Sprintf("missing env vars: %s", strings.Join(missing, ", "))
+			return result
+		}
+		result.State = healthHealthy
+		return result
+	}
+
+	result.State = healthUnknown
+	result.Detail = fmt.Sprintf("unsupported endpoint scheme: %s", srv.Endpoint)
+	return result
+}
+
+// probeAll probes every server concurrently and returns results by name.
+func probeAll(servers []MCPServer) map[string]probeResult {
+	type namedResult struct {
+		name   string
+		result probeResult
+	}
+	ch := make(chan namedResult, len(servers))
+	for i := range servers {
+		go func(srv MCPServer) {
+			ch <- namedResult{name: srv.Name, result: probeServer(&srv)}
+		}(servers[i])
+	}
+	results := make(map[string]probeResult, len(servers))
+	for range servers {
+		named := <-ch
+		results[named.name] = named.result
+	}
+	return results
+}
+
+// healthAge formats the time since a probe compactly (e.g. "12s", "3m").
+func healthAge(since time.Time) string {
+	if since.IsZero() {
+		return "-"
+	}
+	age := time.Since(since)
+	switch {
+	case age < time.Minute:
+		return fmt.Sprintf("%ds", int(age.Seconds()))
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	default:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	}
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A typed health check replaces the free-form health_check string with a
+// small set of probes devgen knows how to run:
+//
+//	tcp://host:port                 dial succeeds within the timeout
+//	http://...?expect=204           GET returns the expected status (default 200)
+//	mcp://                          initialize handshake + ping over stdio
+//	cmd:<shell command>             command exits 0
+//
+// Every form accepts a timeout=<duration> query parameter (cmd: as a
+// "?timeout=" suffix); each type has its own default below.
+type healthCheckSpec struct {
+	Kind    string // "tcp", "http", "mcp", or "cmd"
+	Target  string // address, URL, or command line
+	Expect  int    // expected HTTP status, http only
+	Timeout time.Duration
+}
+
+var healthCheckDefaults = map[string]time.Duration{
+	"tcp":  2 * time.Second,
+	"http": 3 * time.Second,
+	"mcp":  10 * time.Second,
+	"cmd":  5 * time.Second,
+}
+
+// parseHealthCheck parses a health_check value into a typed spec. Legacy
+// free-form values that match no known type are rejected so misspelled
+// checks fail loudly instead of silently passing.
+func parseHealthCheck(raw string) (*healthCheckSpec, error) {
+	spec := &healthCheckSpec{Expect: http.StatusOK}
+
+	if cmdLine, ok := strings.CutPrefix(raw, "cmd:"); ok {
+		spec.Kind = "cmd"
+		spec.Target = cmdLine
+		if line, param, ok := strings.Cut(cmdLine, "?timeout="); ok {
+			timeout, err := time.ParseDuration(param)
+			if err != nil {
+				return nil, fmt.Errorf("health check %q: bad timeout: %w", raw, err)
+			}
+			spec.Target = line
+			spec.Timeout = timeout
+		}
+		if strings.TrimSpace(spec.Target) == "" {
+			return nil, fmt.Errorf("health check %q has an empty command", raw)
+		}
+		applyHealthCheckDefault(spec)
+		return spec, nil
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("health check %q: %w", raw, err)
+	}
+	query := parsed.Query()
+	if value := query.Get("timeout"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("health check %q: bad timeout: %w", raw, err)
+		}
+		spec.Timeout = timeout
+	}
+
+	switch parsed.Scheme {
+	case "tcp":
+		if parsed.Host == "" {
+			return nil, fmt.Errorf("health check %q needs a host:port", raw)
+		}
+		spec.Kind = "tcp"
+		spec.Target = parsed.Host
+	case "http", "https":
+		spec.Kind = "http"
+		if value := query.Get("expect"); value != "" {
+			expect, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("health check %q: bad expect status: %w", raw, err)
+			}
+			spec.Expect = expect
+		}
+		query.Del("expect")
+		query.Del("timeout")
+		parsed.RawQuery = query.Encode()
+		spec.Target = parsed.String()
+	case "mcp":
+		spec.Kind = "mcp"
+	default:
+		return nil, fmt.Errorf("health check %q: unknown type %q (want tcp://, http(s)://, mcp://, or cmd:)",
+			raw, parsed.Scheme)
+	}
+	applyHealthCheckDefault(spec)
+	return spec, nil
+}
+
+func applyHealthCheckDefault(spec *healthCheckSpec) {
+	if spec.Timeout == 0 {
+		spec.Timeout = healthCheckDefaults[spec.Kind]
+	}
+}
+
+// runHealthCheck executes a typed check against one server.
+func runHealthCheck(srv *MCPServer, spec *healthCheckSpec) probeResult {
+	result := probeResult{CheckedAt: time.Now()}
+	switch spec.Kind {
+	case "tcp":
+		conn, err := net.DialTimeout("tcp", spec.Target, spec.Timeout)
+		if err != nil {
+			result.State = healthUnreachable
+			result.Detail = err.Error()
+			return result
+		}
+		conn.Close()
+		result.State = healthHealthy
+	case "http":
+		resp, err := outboundRetryPolicy().doHTTP(outboundHTTPClient(spec.Timeout), func() (*http.Request, error) {
+			return http.NewRequest(http.MethodGet, spec.Target, nil)
+		})
+		if err != nil {
+			result.State = healthUnreachable
+			result.Detail = err.Error()
+			return result
+		}
+		resp.Body.Close()
+		if resp.StatusCode != spec.Expect {
+			result.State = healthDegraded
+			result.Detail = fmt.Sprintf("got status %d, want %d", resp.StatusCode, spec.Expect)
+			return result
+		}
+		result.State = healthHealthy
+	case "mcp":
+		client, err := dialStdioServer(srv)
+		if err != nil {
+			result.State = healthUnreachable
+			result.Detail = err.Error()
+			return result
+		}
+		defer client.Close()
+		if _, err := client.call("ping", nil, spec.Timeout); err != nil {
+			// Older servers don't implement ping; the completed initialize
+			// handshake is evidence enough that the server is alive.
+			var rpcErr *rpcError
+			if !errors.As(err, &rpcErr) || rpcErr.Code != -32601 {
+				result.State = healthDegraded
+				result.Detail = fmt.Sprintf("ping failed: %v", err)
+				return result
+			}
+		}
+		result.State = healthHealthy
+	case "cmd":
+		shell, shellArg := shellCommand()
+		cmd := exec.Command(shell, shellArg, spec.Target)
+		done := make(chan error, 1)
+		if err := cmd.Start(); err != nil {
+			result.State = healthUnreachable
+			result.Detail = err.Error()
+			return result
+		}
+		go func() { done <- cmd.Wait() }()
+		select {
+		case err := <-done:
+			if err != nil {
+				result.State = healthDegraded
+				result.Detail = fmt.Sprintf("command failed: %v", err)
+				return result
+			}
+			result.State = healthHealthy
+		case <-time.After(spec.Timeout):
+			killProcess(cmd.Process.Pid)
+			result.State = healthUnreachable
+			result.Detail = fmt.Sprintf("command timed out after %s", spec.Timeout)
+		}
+	default:
+		result.State = healthUnknown
+		result.Detail = fmt.Sprintf("unknown health check type %q", spec.Kind)
+	}
+	return result
+}
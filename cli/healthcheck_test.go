@@ -0,0 +1,52 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseHealthCheck(t *testing.T) {
+	cases := []struct {
+		raw     string
+		kind    string
+		target  string
+		expect  int
+		timeout time.Duration
+		wantErr bool
+	}{
+		{raw: "tcp://localhost:9200", kind: "tcp", target: "localhost:9200", timeout: 2 * time.Second},
+		{raw: "tcp://db:5432?timeout=500ms", kind: "tcp", target: "db:5432", timeout: 500 * time.Millisecond},
+		{raw: "http://localhost:8080/health", kind: "http", target: "http://localhost:8080/health",
+			expect: http.StatusOK, timeout: 3 * time.Second},
+		{raw: "https://api.example.com/ready?expect=204&timeout=1s", kind: "http",
+			target: "https://api.example.com/ready", expect: http.StatusNoContent, timeout: time.Second},
+		{raw: "mcp://", kind: "mcp", timeout: 10 * time.Second},
+		{raw: "cmd:pg_isready -q", kind: "cmd", target: "pg_isready -q", timeout: 5 * time.Second},
+		{raw: "cmd:redis-cli ping?timeout=1s", kind: "cmd", target: "redis-cli ping", timeout: time.Second},
+		{raw: "tcp://", wantErr: true},
+		{raw: "cmd:", wantErr: true},
+		{raw: "ping the server", wantErr: true},
+		{raw: "tcp://host:1?timeout=forever", wantErr: true},
+	}
+	for _, tc := range cases {
+		spec, err := parseHealthCheck(tc.raw)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("parseHealthCheck(%q): want error, got %+v", tc.raw, spec)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseHealthCheck(%q): %v", tc.raw, err)
+			continue
+		}
+		if spec.Kind != tc.kind || spec.Target != tc.target || spec.Timeout != tc.timeout {
+			t.Errorf("parseHealthCheck(%q) = %+v, want kind=%s target=%q timeout=%s",
+				tc.raw, spec, tc.kind, tc.target, tc.timeout)
+		}
+		if tc.kind == "http" && spec.Expect != tc.expect {
+			t.Errorf("parseHealthCheck(%q) expect = %d, want %d", tc.raw, spec.Expect, tc.expect)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+// historyDirName holds pre-write registry snapshots beside the registry,
+// newest restorable via 'devgen undo'.
+const historyDirName = ".devgen_history"
+
+// historyKeep caps how many snapshots are retained.
+const historyKeep = 20
+
+func historyDir(registryPath string) string {
+	return filepath.Join(filepath.Dir(registryPath), historyDirName)
+}
+
+// recordRegistrySnapshot saves the registry content about to be replaced,
+// pruning the oldest snapshots beyond historyKeep. Failures are not fatal:
+// losing a snapshot must not block the write itself.
+func recordRegistrySnapshot(registryPath string, content []byte) {
+	dir := historyDir(registryPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return
+	}
+	names, err := snapshotNames(registryPath)
+	if err != nil {
+		return
+	}
+	next := 1
+	if len(names) > 0 {
+		fmt.Sscanf(names[len(names)-1], "%d", &next)
+		next++
+	}
+	name := fmt.Sprintf("%012d.json", next)
+	if err := os.WriteFile(filepath.Join(dir, name), content, 0o644); err != nil {
+		return
+	}
+	for len(names) >= historyKeep {
+		os.Remove(filepath.Join(dir, names[0]))
+		names = names[1:]
+	}
+}
+
+// snapshotNames lists snapshot files oldest-first.
+func snapshotNames(registryPath string) ([]string, error) {
+	entries, err := os.ReadDir(historyDir(registryPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".json" {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Revert the most recent registry mutation",
+	Long: `Restores the registry to its state before the last write devgen made
+(toggle, tag, category change, ...). Each undo pops one snapshot, so
+repeating it walks further back through the last ` + fmt.Sprint(historyKeep) + ` writes.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		names, err := snapshotNames(path)
+		if err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			return fmt.Errorf("nothing to undo: no registry snapshots recorded")
+		}
+		snapPath := filepath.Join(historyDir(path), names[len(names)-1])
+		snapshot, err := os.ReadFile(snapPath)
+		if err != nil {
+			return err
+		}
+		current, _ := os.ReadFile(path)
+		if dryRunFlag {
+			printDryRunDiff(path, string(current), string(snapshot))
+			return nil
+		}
+		if err := confirmAction(fmt.Sprintf("overwrite %s with the previous snapshot", path)); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, snapshot, 0o644); err != nil {
+			return err
+		}
+		os.Remove(snapPath)
+		fmt.Printf("Reverted %s to its previous state (%d undo step(s) left)\n", path, len(names)-1)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(undoCmd)
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// Lifecycle hooks let integrations react to supervision events — a server
+// starting, a registry status change, a damped health failure — without
+// patching every call site. Compiled-in extensions implement
+// lifecycleHook and register themselves from an init function; external
+// integrations configure command hooks that receive the same events as
+// JSON on stdin, which is also the wire shape a go-plugin bridge would
+// speak.
+type lifecycleHook interface {
+	OnServerStart(server string, pid int)
+	OnStatusChange(server, from, to string)
+	OnHealthFail(server, detail string)
+}
+
+// hooksConfig is the config block wiring external command hooks: each
+// command runs through the shell with one JSON event on stdin.
+type hooksConfig struct {
+	Commands []string `json:"commands,omitempty"`
+}
+
+var (
+	hooksMu      sync.Mutex
+	hooks        []lifecycleHook
+	hookInitOnce sync.Once
+)
+
+// registerLifecycleHook installs a compiled-in hook; call it from init.
+func registerLifecycleHook(h lifecycleHook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	hooks = append(hooks, h)
+}
+
+// initConfiguredHooks installs command hooks from config on first fire,
+// so plain commands that never emit events skip the config read.
+func initConfiguredHooks() {
+	hookInitOnce.Do(func() {
+		cfg, err := loadConfig()
+		if err != nil {
+			return
+		}
+		for _, command := range cfg.Hooks.Commands {
+			registerLifecycleHook(&commandHook{command: command})
+		}
+	})
+}
+
+func snapshotHooks() []lifecycleHook {
+	initConfiguredHooks()
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	return append([]lifecycleHook(nil), hooks...)
+}
+
+// Dispatch is asynchronous: a slow ticket-creation hook must never stall
+// a health sweep. A panicking hook is logged and skipped.
+func dispatchHooks(call func(lifecycleHook)) {
+	targets := snapshotHooks()
+	if len(targets) == 0 {
+		return
+	}
+	go func() {
+		for _, h := range targets {
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						debugf("hooks: hook panicked: %v", r)
+					}
+				}()
+				call(h)
+			}()
+		}
+	}()
+}
+
+func fireServerStart(server string, pid int) {
+	publishEvent("server_start", server, map[string]string{"pid": fmt.Sprintf("%d", pid)})
+	dispatchHooks(func(h lifecycleHook) { h.OnServerStart(server, pid) })
+}
+
+func fireStatusChange(server, from, to string) {
+	publishEvent("status_change", server, map[string]string{"from": from, "to": to})
+	dispatchHooks(func(h lifecycleHook) { h.OnStatusChange(server, from, to) })
+}
+
+func fireHealthFail(server, detail string) {
+	publishEvent("health_fail", server, map[string]string{"detail": detail})
+	dispatchHooks(func(h lifecycleHook) { h.OnHealthFail(server, detail) })
+}
+
+// hookEvent is the JSON document command hooks read from stdin.
+type hookEvent struct {
+	Event  string `json:"event"`
+	Server string `json:"server"`
+	PID    int    `json:"pid,omitempty"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+	Detail string `json:"detail,omitempty"`
+	At     string `json:"at"`
+}
+
+// commandHook adapts one configured shell command to the hook interface.
+type commandHook struct {
+	command string
+}
+
+// commandHookTimeout bounds one hook invocation.
+const commandHookTimeout = 10 * time.Second
+
+func (c *commandHook) deliver(event hookEvent) {
+	event.At = time.Now().UTC().Format(time.RFC3339)
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	cmd := exec.Command("sh", "-c", c.command)
+	cmd.Stdin = bytes.NewReader(payload)
+	if err := cmd.Start(); err != nil {
+		debugf("hooks: starting %q: %v", c.command, err)
+		return
+	}
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			debugf("hooks: %q on %s: %v", c.command, event.Event, err)
+		}
+	case <-time.After(commandHookTimeout):
+		cmd.Process.Kill()
+		debugf("hooks: %q timed out after %s", c.command, commandHookTimeout)
+	}
+}
+
+func (c *commandHook) OnServerStart(server string, pid int) {
+	c.deliver(hookEvent{Event: "server_start", Server: server, PID: pid})
+}
+
+func (c *commandHook) OnStatusChange(server, from, to string) {
+	c.deliver(hookEvent{Event: "status_change", Server: server, From: from, To: to})
+}
+
+func (c *commandHook) OnHealthFail(server, detail string) {
+	c.deliver(hookEvent{Event: "health_fail", Server: server, Detail: detail})
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// recordingHook captures events on a channel for assertions.
+type recordingHook struct {
+	events chan string
+}
+
+func (r *recordingHook) OnServerStart(server string, pid int) {
+	r.events <- "start:" + server
+}
+
+func (r *recordingHook) OnStatusChange(server, from, to string) {
+	r.events <- "status:" + server + ":" + from + ">" + to
+}
+
+func (r *recordingHook) OnHealthFail(server, detail string) {
+	r.events <- "fail:" + server + ":" + detail
+}
+
+func awaitHookEvent(t *testing.T, ch chan string) string {
+	t.Helper()
+	select {
+	case got := <-ch:
+		return got
+	case <-time.After(5 * time.Second):
+		t.Fatal("no hook event within 5s")
+		return ""
+	}
+}
+
+func TestLifecycleHookDispatch(t *testing.T) {
+	hook := &recordingHook{events: make(chan string, 8)}
+	registerLifecycleHook(hook)
+	defer func() {
+		hooksMu.Lock()
+		hooks = hooks[:len(hooks)-1]
+		hooksMu.Unlock()
+	}()
+
+	fireServerStart("context7", 4242)
+	if got := awaitHookEvent(t, hook.events); got != "start:context7" {
+		t.Errorf("start event = %q", got)
+	}
+	fireStatusChange("context7", "active", "locked")
+	if got := awaitHookEvent(t, hook.events); got != "status:context7:active>locked" {
+		t.Errorf("status event = %q", got)
+	}
+	fireHealthFail("context7", "timed out")
+	if got := awaitHookEvent(t, hook.events); got != "fail:context7:timed out" {
+		t.Errorf("fail event = %q", got)
+	}
+}
+
+func TestCommandHookDelivers(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "event.json")
+	hook := &commandHook{command: "cat > " + out}
+	hook.OnHealthFail("surrealdb", "connection refused")
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var event hookEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		t.Fatalf("hook received invalid JSON %q: %v", data, err)
+	}
+	if event.Event != "health_fail" || event.Server != "surrealdb" || event.Detail != "connection refused" {
+		t.Errorf("event = %+v", event)
+	}
+	if event.At == "" {
+		t.Error("event timestamp missing")
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// All outbound HTTP — health probes, the registry endpoint, port scans,
+// log shipping — shares one tuned transport so connections are pooled and
+// reused instead of being torn down after every request. The transport
+// honors proxy settings from the environment (HTTP_PROXY, HTTPS_PROXY,
+// NO_PROXY) and negotiates HTTP/2 where the server supports it.
+// Per-request deadlines stay with the callers: each site wraps the shared
+// transport in a client carrying its own timeout.
+
+// httpTuningConfig overrides transport-level timeouts and pool sizing.
+// Durations are Go duration strings ("5s", "750ms"); zero values keep the
+// defaults.
+type httpTuningConfig struct {
+	DialTimeout           string `json:"dial_timeout,omitempty"`
+	TLSHandshakeTimeout   string `json:"tls_handshake_timeout,omitempty"`
+	ResponseHeaderTimeout string `json:"response_header_timeout,omitempty"`
+	MaxIdleConnsPerHost   int    `json:"max_idle_conns_per_host,omitempty"`
+	// CABundle is a PEM file appended to the system roots, for networks
+	// behind TLS-intercepting proxies. InsecureSkipVerify disables
+	// certificate verification entirely and is announced loudly on every
+	// startup; prefer ca_bundle.
+	CABundle           string `json:"ca_bundle,omitempty"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty"`
+}
+
+var (
+	transportOnce   sync.Once
+	sharedTransport http.RoundTripper
+)
+
+// outboundTransport builds the shared transport on first use. Config
+// errors fall back to defaults with a warning rather than failing the
+// command: a typo in a tuning knob should not take down health checks.
+func outboundTransport() http.RoundTripper {
+	transportOnce.Do(func() {
+		dialTimeout := 5 * time.Second
+		tlsTimeout := 5 * time.Second
+		headerTimeout := 10 * time.Second
+		perHost := 8
+		var tlsConfig *tls.Config
+		if cfg, err := loadConfig(); err == nil {
+			tlsConfig = outboundTLSConfig(cfg.HTTP)
+			applyDuration := func(name, value string, out *time.Duration) {
+				if value == "" {
+					return
+				}
+				d, err := time.ParseDuration(value)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "WARNING: config http.%s: %v (using default)\n", name, err)
+					return
+				}
+				*out = d
+			}
+			applyDuration("dial_timeout", cfg.HTTP.DialTimeout, &dialTimeout)
+			applyDuration("tls_handshake_timeout", cfg.HTTP.TLSHandshakeTimeout, &tlsTimeout)
+			applyDuration("response_header_timeout", cfg.HTTP.ResponseHeaderTimeout, &headerTimeout)
+			if cfg.HTTP.MaxIdleConnsPerHost > 0 {
+				perHost = cfg.HTTP.MaxIdleConnsPerHost
+			}
+		}
+		sharedTransport = &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   dialTimeout,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          64,
+			MaxIdleConnsPerHost:   perHost,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   tlsTimeout,
+			ResponseHeaderTimeout: headerTimeout,
+			TLSClientConfig:       tlsConfig,
+		}
+	})
+	return sharedTransport
+}
+
+// outboundTLSConfig builds TLS settings from the http config block. A CA
+// bundle that cannot be loaded is reported and skipped — connections then
+// fail certificate verification rather than silently trusting anything.
+func outboundTLSConfig(cfg httpTuningConfig) *tls.Config {
+	if cfg.InsecureSkipVerify {
+		fmt.Fprintln(os.Stderr, "WARNING: http.insecure_skip_verify is set; TLS certificates are NOT being verified")
+		return &tls.Config{InsecureSkipVerify: true}
+	}
+	if cfg.CABundle == "" {
+		return nil
+	}
+	pem, err := os.ReadFile(cfg.CABundle)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: config http.ca_bundle: %v (using system roots only)\n", err)
+		return nil
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		fmt.Fprintf(os.Stderr, "WARNING: config http.ca_bundle: no certificates found in %s (using system roots only)\n", cfg.CABundle)
+		return nil
+	}
+	return &tls.Config{RootCAs: pool}
+}
+
+// outboundHTTPClient returns a client over the shared pooled transport
+// with the given overall request timeout.
+func outboundHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: outboundTransport()}
+}
+
+var (
+	retryPolicyOnce sync.Once
+	retryPolicyVal  retryPolicy
+)
+
+// outboundRetryPolicy returns the configured retry policy, loaded once
+// per process alongside the shared transport.
+func outboundRetryPolicy() retryPolicy {
+	retryPolicyOnce.Do(func() {
+		retryPolicyVal = loadRetryPolicy()
+	})
+	return retryPolicyVal
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTestCA writes a freshly generated self-signed CA to a PEM file.
+func writeTestCA(t *testing.T) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "devgen test CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestOutboundTLSConfig(t *testing.T) {
+	if got := outboundTLSConfig(httpTuningConfig{}); got != nil {
+		t.Errorf("empty config should keep default TLS settings, got %+v", got)
+	}
+
+	got := outboundTLSConfig(httpTuningConfig{InsecureSkipVerify: true})
+	if got == nil || !got.InsecureSkipVerify {
+		t.Errorf("insecure_skip_verify not honored: %+v", got)
+	}
+
+	got = outboundTLSConfig(httpTuningConfig{CABundle: writeTestCA(t)})
+	if got == nil || got.RootCAs == nil {
+		t.Fatalf("valid CA bundle should produce a root pool, got %+v", got)
+	}
+	if got.InsecureSkipVerify {
+		t.Error("CA bundle must not disable verification")
+	}
+
+	// A missing or garbage bundle falls back to system roots so that
+	// connections fail verification instead of trusting anything.
+	if got := outboundTLSConfig(httpTuningConfig{CABundle: filepath.Join(t.TempDir(), "absent.pem")}); got != nil {
+		t.Errorf("missing bundle should fall back to defaults, got %+v", got)
+	}
+	garbage := filepath.Join(t.TempDir(), "garbage.pem")
+	if err := os.WriteFile(garbage, []byte("not a certificate"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := outboundTLSConfig(httpTuningConfig{CABundle: garbage}); got != nil {
+		t.Errorf("garbage bundle should fall back to defaults, got %+v", got)
+	}
+}
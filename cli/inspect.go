@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	inspectUpdateFlag       bool
+	inspectCapabilitiesFlag bool
+)
+
+var inspectCmd = &cobra.Command{
+	Use:   "inspect <server>",
+	Short: "Connect to a server and enumerate its tools, resources, and prompts",
+	Long: `Launches the named stdio MCP server, performs the initialize handshake,
+and enumerates everything it exposes: tools, resources, and prompts. With
+--update, the discovered names are written back into the registry entry so
+list output and exports stay accurate.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		srv := reg.findServer(args[0])
+		if srv == nil {
+			return serverNotFoundError(args[0])
+		}
+
+		client, err := dialStdioServer(srv)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		if inspectCapabilitiesFlag {
+			printCapabilityReport(srv, client)
+			return nil
+		}
+
+		tools, err := client.listTools()
+		if err != nil {
+			return fmt.Errorf("tools/list: %w", err)
+		}
+		fmt.Printf("Server: %s\n\nTools (%d):\n", srv.Name, len(tools))
+		for _, tool := range tools {
+			fmt.Printf("  %-32s %s\n", tool.Name, firstLine(tool.Description))
+		}
+
+		// Resources and prompts are optional capabilities; a method-not-found
+		// error just means the server does not implement them.
+		resources, resErr := client.listResources()
+		if resErr != nil {
+			fmt.Printf("\nResources: not supported (%v)\n", resErr)
+		} else {
+			fmt.Printf("\nResources (%d):\n", len(resources))
+			for _, res := range resources {
+				fmt.Printf("  %-32s %s\n", res.URI, firstLine(res.Description))
+			}
+		}
+
+		prompts, promptErr := client.listPrompts()
+		if promptErr != nil {
+			fmt.Printf("\nPrompts: not supported (%v)\n", promptErr)
+		} else {
+			fmt.Printf("\nPrompts (%d):\n", len(prompts))
+			for _, prompt := range prompts {
+				fmt.Printf("  %-32s %s\n", prompt.Name, firstLine(prompt.Description))
+			}
+		}
+
+		if inspectUpdateFlag {
+			srv.Tools = srv.Tools[:0]
+			for _, tool := range tools {
+				srv.Tools = append(srv.Tools, tool.Name)
+			}
+			srv.Resources = nil
+			for _, res := range resources {
+				srv.Resources = append(srv.Resources, res.URI)
+			}
+			srv.Prompts = nil
+			for _, prompt := range prompts {
+				srv.Prompts = append(srv.Prompts, prompt.Name)
+			}
+			if err := saveMCPRegistry(path, reg); err != nil {
+				return err
+			}
+			fmt.Printf("\nRegistry entry updated: %d tools, %d resources, %d prompts\n",
+				len(srv.Tools), len(srv.Resources), len(srv.Prompts))
+		}
+		return nil
+	},
+}
+
+// firstLine returns the first line of a possibly multi-line description.
+func firstLine(s string) string {
+	if idx := strings.IndexByte(s, '\n'); idx >= 0 {
+		return s[:idx]
+	}
+	return s
+}
+
+func init() {
+	inspectCmd.Flags().BoolVar(&inspectUpdateFlag, "update", false, "write discovered tools/resources/prompts back to the registry")
+	inspectCmd.Flags().BoolVar(&inspectCapabilitiesFlag, "capabilities", false, "report protocol version and advertised capabilities")
+	rootCmd.AddCommand(inspectCmd)
+}
@@ -0,0 +1,42 @@
+package testutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+)
+
+// HealthServer is an HTTP server whose /health endpoint can be steered by
+// tests: flip the status code or add latency mid-test to exercise the
+// degraded and timeout paths of the health checker.
+type HealthServer struct {
+	*httptest.Server
+	status  atomic.Int64
+	latency atomic.Int64 // nanoseconds
+}
+
+// NewHealthServer starts an HTTP server answering every request with the
+// given status after the given delay. Close it when done.
+func NewHealthServer(status int, latency time.Duration) *HealthServer {
+	hs := &HealthServer{}
+	hs.status.Store(int64(status))
+	hs.latency.Store(int64(latency))
+	hs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if d := time.Duration(hs.latency.Load()); d > 0 {
+			time.Sleep(d)
+		}
+		w.WriteHeader(int(hs.status.Load()))
+	}))
+	return hs
+}
+
+// SetStatus changes the status code returned from now on.
+func (hs *HealthServer) SetStatus(status int) {
+	hs.status.Store(int64(status))
+}
+
+// SetLatency changes the response delay from now on.
+func (hs *HealthServer) SetLatency(latency time.Duration) {
+	hs.latency.Store(int64(latency))
+}
@@ -0,0 +1,150 @@
+// Package testutil provides scripted fake MCP servers for end-to-end
+// tests: an in-process JSON-RPC loop that can run over any reader/writer
+// pair, a re-exec hook that turns the test binary itself into a stdio MCP
+// server, and an HTTP health endpoint with configurable latency and
+// status. Nothing here fabricates success: the fakes really speak the
+// protocol, with failures injected exactly where a script asks for them.
+package testutil
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// scriptEnvVar carries a JSON-encoded Script into the re-exec'd test
+// binary.
+const scriptEnvVar = "DEVGEN_TESTUTIL_MCP"
+
+// Script configures a fake MCP server's behavior.
+type Script struct {
+	// Latency delays every response.
+	Latency time.Duration `json:"latency,omitempty"`
+	// FailMethods maps method names to be answered with a JSON-RPC error.
+	FailMethods map[string]bool `json:"fail_methods,omitempty"`
+	// Tools are returned from tools/list.
+	Tools []string `json:"tools,omitempty"`
+	// ExitAfter terminates the server after this many requests, simulating
+	// a crash; zero means never.
+	ExitAfter int `json:"exit_after,omitempty"`
+}
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Serve runs a scripted MCP server over the given streams until EOF, an
+// unrecoverable error, or the script's ExitAfter budget runs out.
+func Serve(r io.Reader, w io.Writer, script Script) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	enc := json.NewEncoder(w)
+	served := 0
+	for scanner.Scan() {
+		var req rpcRequest
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			continue
+		}
+		if req.ID == nil {
+			continue // notification
+		}
+		served++
+		if script.ExitAfter > 0 && served > script.ExitAfter {
+			return fmt.Errorf("script: exiting after %d requests", script.ExitAfter)
+		}
+		if script.Latency > 0 {
+			time.Sleep(script.Latency)
+		}
+		resp := rpcResponse{JSONRPC: "2.0", ID: req.ID}
+		if script.FailMethods[req.Method] {
+			resp.Error = &rpcError{Code: -32000, Message: "scripted failure for " + req.Method}
+		} else {
+			resp.Result = resultFor(req.Method, script)
+		}
+		if err := enc.Encode(resp); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func resultFor(method string, script Script) interface{} {
+	switch method {
+	case "initialize":
+		return map[string]interface{}{
+			"protocolVersion": "2024-11-05",
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+			"serverInfo":      map[string]interface{}{"name": "testutil-fake", "version": "0.0.0"},
+		}
+	case "ping":
+		return map[string]interface{}{}
+	case "tools/list":
+		tools := make([]map[string]interface{}, 0, len(script.Tools))
+		for _, name := range script.Tools {
+			tools = append(tools, map[string]interface{}{
+				"name":        name,
+				"description": "scripted tool",
+			})
+		}
+		return map[string]interface{}{"tools": tools}
+	}
+	return map[string]interface{}{}
+}
+
+// RunMainIfScripted turns the current process into a stdio MCP server when
+// the script environment variable is set, and reports whether it did.
+// Call it from TestMain before m.Run:
+//
+//	func TestMain(m *testing.M) {
+//		if testutil.RunMainIfScripted() {
+//			return
+//		}
+//		os.Exit(m.Run())
+//	}
+func RunMainIfScripted() bool {
+	raw := os.Getenv(scriptEnvVar)
+	if raw == "" {
+		return false
+	}
+	var script Script
+	if err := json.Unmarshal([]byte(raw), &script); err != nil {
+		fmt.Fprintf(os.Stderr, "testutil: bad script: %v\n", err)
+		os.Exit(2)
+	}
+	if err := Serve(os.Stdin, os.Stdout, script); err != nil {
+		fmt.Fprintf(os.Stderr, "testutil: %v\n", err)
+		os.Exit(1)
+	}
+	return true
+}
+
+// ServerCommand returns the command, args, and extra environment that
+// launch the test binary as a stdio MCP server running the script.
+func ServerCommand(script Script) (command string, env map[string]string, err error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", nil, err
+	}
+	raw, err := json.Marshal(script)
+	if err != nil {
+		return "", nil, err
+	}
+	return exe, map[string]string{scriptEnvVar: string(raw)}, nil
+}
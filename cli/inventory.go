@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Inventory rendering for 'server list --output csv|markdown': the same
+// rows as the table view in formats that paste cleanly into spreadsheets
+// and wikis.
+
+func inventoryHeader(wide bool) []string {
+	header := []string{"name", "category", "status", "version", "owner", "tools", "resources", "prompts", "enabled"}
+	if wide {
+		header = append(header, "note")
+	}
+	return header
+}
+
+func inventoryRow(s serverSummary, wide bool) []string {
+	enabled := "no"
+	if s.Enabled {
+		enabled = "yes"
+	}
+	row := []string{
+		s.Name, s.Category, s.Status, s.Version, s.Owner,
+		strconv.Itoa(s.ToolCount), strconv.Itoa(s.ResourceCount), strconv.Itoa(s.PromptCount),
+		enabled,
+	}
+	if wide {
+		row = append(row, latestNote(s.Notes))
+	}
+	return row
+}
+
+func renderInventoryCSV(summaries []serverSummary, wide bool) error {
+	w := csv.NewWriter(os.Stdout)
+	if err := w.Write(inventoryHeader(wide)); err != nil {
+		return err
+	}
+	for _, s := range summaries {
+		if err := w.Write(inventoryRow(s, wide)); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func renderInventoryMarkdown(summaries []serverSummary, wide bool) {
+	header := inventoryHeader(wide)
+	fmt.Printf("| %s |\n", strings.Join(header, " | "))
+	rule := make([]string, len(header))
+	for i := range rule {
+		rule[i] = "---"
+	}
+	fmt.Printf("| %s |\n", strings.Join(rule, " | "))
+	for _, s := range summaries {
+		row := inventoryRow(s, wide)
+		for i, cell := range row {
+			row[i] = strings.ReplaceAll(cell, "|", "\\|")
+		}
+		fmt.Printf("| %s |\n", strings.Join(row, " | "))
+	}
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The JSON viewer renders tool call results as a foldable tree instead of
+// a wall of raw JSON: arrow keys scroll, enter folds and unfolds nodes,
+// and y/Y copy the document or the selected subtree to the clipboard. It
+// runs standalone for 'devgen call' and embedded in the playground.
+
+// jsonNode is one entry in the parsed tree.
+type jsonNode struct {
+	label     string // key or index; "" at the root
+	value     interface{}
+	children  []*jsonNode
+	collapsed bool
+	depth     int
+}
+
+func (n *jsonNode) isBranch() bool { return len(n.children) > 0 }
+
+// buildJSONTree converts a decoded value into nodes. Object keys are
+// sorted so rendering is stable.
+func buildJSONTree(label string, value interface{}, depth int) *jsonNode {
+	node := &jsonNode{label: label, value: value, depth: depth}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			node.children = append(node.children, buildJSONTree(key, v[key], depth+1))
+		}
+	case []interface{}:
+		for i, inner := range v {
+			node.children = append(node.children, buildJSONTree(fmt.Sprintf("[%d]", i), inner, depth+1))
+		}
+	}
+	return node
+}
+
+// jsonViewerStyles colors the tree; they degrade to plain text on dumb
+// terminals the same way the dashboard styles do.
+type jsonViewerStyles struct {
+	key     lipgloss.Style
+	str     lipgloss.Style
+	num     lipgloss.Style
+	boolean lipgloss.Style
+	null    lipgloss.Style
+	summary lipgloss.Style
+	cursor  lipgloss.Style
+	footer  lipgloss.Style
+}
+
+func newJSONViewerStyles() jsonViewerStyles {
+	return jsonViewerStyles{
+		key:     lipgloss.NewStyle().Foreground(lipgloss.Color("14")),
+		str:     lipgloss.NewStyle().Foreground(lipgloss.Color("10")),
+		num:     lipgloss.NewStyle().Foreground(lipgloss.Color("11")),
+		boolean: lipgloss.NewStyle().Foreground(lipgloss.Color("13")),
+		null:    lipgloss.NewStyle().Foreground(lipgloss.Color("8")),
+		summary: lipgloss.NewStyle().Foreground(lipgloss.Color("8")),
+		cursor:  lipgloss.NewStyle().Reverse(true),
+		footer:  lipgloss.NewStyle().Foreground(lipgloss.Color("8")),
+	}
+}
+
+type jsonViewerModel struct {
+	root    *jsonNode
+	visible []*jsonNode
+	raw     []byte
+	cursor  int
+	offset  int
+	width   int
+	height  int
+	status  string
+	styles  jsonViewerStyles
+}
+
+// newJSONViewer parses raw JSON into a viewer model.
+func newJSONViewer(raw []byte) (*jsonViewerModel, error) {
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, fmt.Errorf("not valid JSON: %w", err)
+	}
+	m := &jsonViewerModel{
+		root:   buildJSONTree("", value, 0),
+		raw:    raw,
+		width:  defaultDashboardWidth,
+		height: 24,
+		styles: newJSONViewerStyles(),
+	}
+	m.reflow()
+	return m, nil
+}
+
+// reflow rebuilds the visible node list after a fold change.
+func (m *jsonViewerModel) reflow() {
+	m.visible = m.visible[:0]
+	var walk func(n *jsonNode)
+	walk = func(n *jsonNode) {
+		m.visible = append(m.visible, n)
+		if n.collapsed {
+			return
+		}
+		for _, child := range n.children {
+			walk(child)
+		}
+	}
+	walk(m.root)
+	if m.cursor >= len(m.visible) {
+		m.cursor = len(m.visible) - 1
+	}
+}
+
+// viewerKey handles one key press shared by the standalone program and
+// the playground embedding. It returns false when the viewer should
+// close.
+func (m *jsonViewerModel) viewerKey(msg tea.KeyMsg) bool {
+	switch msg.String() {
+	case "q", "esc":
+		return false
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.visible)-1 {
+			m.cursor++
+		}
+	case "g":
+		m.cursor = 0
+	case "G":
+		m.cursor = len(m.visible) - 1
+	case "enter", " ":
+		if node := m.visible[m.cursor]; node.isBranch() {
+			node.collapsed = !node.collapsed
+			m.reflow()
+		}
+	case "left", "h":
+		if node := m.visible[m.cursor]; node.isBranch() && !node.collapsed {
+			node.collapsed = true
+			m.reflow()
+		}
+	case "right", "l":
+		if node := m.visible[m.cursor]; node.isBranch() && node.collapsed {
+			node.collapsed = false
+			m.reflow()
+		}
+	case "y":
+		m.yank(m.raw, "document")
+	case "Y":
+		node := m.visible[m.cursor]
+		data, err := json.MarshalIndent(node.value, "", "  ")
+		if err != nil {
+			m.status = fmt.Sprintf("yank failed: %v", err)
+			break
+		}
+		label := node.label
+		if label == "" {
+			label = "document"
+		}
+		m.yank(data, label)
+	}
+	m.clampScroll()
+	return true
+}
+
+// yank copies data to the system clipboard, reporting failure honestly —
+// headless hosts without a clipboard helper get the error, not a fake
+// "copied" message.
+func (m *jsonViewerModel) yank(data []byte, what string) {
+	if err := clipboard.WriteAll(string(data)); err != nil {
+		m.status = fmt.Sprintf("clipboard unavailable: %v", err)
+		return
+	}
+	m.status = fmt.Sprintf("copied %s (%d bytes)", what, len(data))
+}
+
+func (m *jsonViewerModel) clampScroll() {
+	rows := m.height - 2 // footer
+	if rows < 1 {
+		rows = 1
+	}
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+}
+
+// renderLine renders one visible node. The selected row is rendered from
+// unstyled text inside the cursor style, so escape sequences never nest.
+func (m *jsonViewerModel) renderLine(node *jsonNode, selected bool) string {
+	indent := strings.Repeat("  ", node.depth)
+	marker := "  "
+	if node.isBranch() {
+		marker = "▾ "
+		if node.collapsed {
+			marker = "▸ "
+		}
+	}
+
+	var plain, styled string
+	if node.isBranch() {
+		summary := fmt.Sprintf("{…} %d keys", len(node.children))
+		if _, isArray := node.value.([]interface{}); isArray {
+			summary = fmt.Sprintf("[…] %d items", len(node.children))
+		}
+		plain = summary
+		styled = m.styles.summary.Render(summary)
+	} else {
+		plain = leafText(node.value)
+		styled = m.styleLeaf(node.value, plain)
+	}
+	if node.label != "" {
+		plain = node.label + ": " + plain
+		styled = m.styles.key.Render(node.label) + ": " + styled
+	}
+	if selected {
+		return m.styles.cursor.Render(padTo(indent+marker+plain, m.width))
+	}
+	return indent + marker + styled
+}
+
+func leafText(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func (m *jsonViewerModel) styleLeaf(value interface{}, text string) string {
+	switch value.(type) {
+	case string:
+		return m.styles.str.Render(text)
+	case float64:
+		return m.styles.num.Render(text)
+	case bool:
+		return m.styles.boolean.Render(text)
+	case nil:
+		return m.styles.null.Render(text)
+	}
+	return text
+}
+
+func (m *jsonViewerModel) view() string {
+	rows := m.height - 2
+	if rows < 1 {
+		rows = 1
+	}
+	end := m.offset + rows
+	if end > len(m.visible) {
+		end = len(m.visible)
+	}
+	var b strings.Builder
+	for i := m.offset; i < end; i++ {
+		b.WriteString(m.renderLine(m.visible[i], i == m.cursor) + "\n")
+	}
+	footer := "↑/↓ move • enter fold • y yank all • Y yank node • q close"
+	if m.status != "" {
+		footer = m.status
+	}
+	b.WriteString(m.styles.footer.Render(footer))
+	return b.String()
+}
+
+// Init, Update, and View adapt the viewer to a standalone tea program for
+// 'devgen call'.
+func (m *jsonViewerModel) Init() tea.Cmd { return nil }
+
+func (m *jsonViewerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.clampScroll()
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" || !m.viewerKey(msg) {
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m *jsonViewerModel) View() string { return m.view() }
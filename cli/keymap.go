@@ -0,0 +1,104 @@
+package main
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// dashboardKeyMap holds every dashboard action binding. It implements
+// help.KeyMap so the bubbles/help overlay can render it.
+type dashboardKeyMap struct {
+	Up       key.Binding
+	Down     key.Binding
+	Toggle   key.Binding
+	Play     key.Binding
+	Sort     key.Binding
+	Group    key.Binding
+	Collapse key.Binding
+	Search   key.Binding
+	Reload   key.Binding
+	Help     key.Binding
+	Quit     key.Binding
+}
+
+func (k dashboardKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Toggle, k.Sort, k.Group, k.Help, k.Quit}
+}
+
+func (k dashboardKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Collapse},
+		{k.Toggle, k.Play, k.Sort, k.Group},
+		{k.Search, k.Reload, k.Help, k.Quit},
+	}
+}
+
+// keymapPresets are the built-in binding sets selectable via the config
+// file's keymap.preset field.
+var keymapPresets = map[string]func() dashboardKeyMap{
+	"default": defaultKeyMap,
+	"vim":     vimKeyMap,
+	"arrows":  arrowsKeyMap,
+}
+
+func defaultKeyMap() dashboardKeyMap {
+	return dashboardKeyMap{
+		Up:       key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
+		Down:     key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down")),
+		Toggle:   key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "toggle server")),
+		Play:     key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "tool playground")),
+		Sort:     key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "cycle sort")),
+		Group:    key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "group by category")),
+		Collapse: key.NewBinding(key.WithKeys("enter", " "), key.WithHelp("enter", "collapse group")),
+		Search:   key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
+		Reload:   key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "reload registry")),
+		Help:     key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
+		Quit:     key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+	}
+}
+
+func vimKeyMap() dashboardKeyMap {
+	keymap := defaultKeyMap()
+	keymap.Up = key.NewBinding(key.WithKeys("k"), key.WithHelp("k", "up"))
+	keymap.Down = key.NewBinding(key.WithKeys("j"), key.WithHelp("j", "down"))
+	keymap.Reload = key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "reload registry"))
+	return keymap
+}
+
+func arrowsKeyMap() dashboardKeyMap {
+	keymap := defaultKeyMap()
+	keymap.Up = key.NewBinding(key.WithKeys("up"), key.WithHelp("↑", "up"))
+	keymap.Down = key.NewBinding(key.WithKeys("down"), key.WithHelp("↓", "down"))
+	return keymap
+}
+
+// keymapFromConfig builds the active keymap: preset first, then per-action
+// overrides from the config file.
+func keymapFromConfig(cfg keymapConfig) dashboardKeyMap {
+	preset := cfg.Preset
+	if preset == "" {
+		preset = "default"
+	}
+	builder, ok := keymapPresets[preset]
+	if !ok {
+		builder = defaultKeyMap
+	}
+	keymap := builder()
+
+	override := func(binding *key.Binding, action string) {
+		if keys, ok := cfg.Bindings[action]; ok && len(keys) > 0 {
+			*binding = key.NewBinding(key.WithKeys(keys...), key.WithHelp(keys[0], binding.Help().Desc))
+		}
+	}
+	override(&keymap.Up, "up")
+	override(&keymap.Down, "down")
+	override(&keymap.Toggle, "toggle")
+	override(&keymap.Play, "play")
+	override(&keymap.Sort, "sort")
+	override(&keymap.Group, "group")
+	override(&keymap.Collapse, "collapse")
+	override(&keymap.Search, "search")
+	override(&keymap.Reload, "reload")
+	override(&keymap.Help, "help")
+	override(&keymap.Quit, "quit")
+	return keymap
+}
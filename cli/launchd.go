@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// launchdLabelPrefix namespaces the generated LaunchAgent labels.
+const launchdLabelPrefix = "ai.devq.machina."
+
+var exportLaunchdCmd = &cobra.Command{
+	Use:   "launchd [server...]",
+	Short: "Generate launchd plists for machina services on macOS",
+	Long: `Writes LaunchAgents property lists for the devgen registry endpoint and
+for MCP servers so they start at login on macOS hosts. Without arguments,
+plists are generated for the registry service and every enabled server
+with a resolvable launch command.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(exportOutputFlag, 0o755); err != nil {
+			return err
+		}
+
+		servers, err := selectExportServers(reg, args)
+		if err != nil {
+			return err
+		}
+
+		written := []string{}
+		plist, err := servePlist()
+		if err != nil {
+			return err
+		}
+		serveFile := filepath.Join(exportOutputFlag, launchdLabelPrefix+"serve.plist")
+		if err := os.WriteFile(serveFile, []byte(plist), 0o644); err != nil {
+			return err
+		}
+		written = append(written, serveFile)
+
+		for _, srv := range servers {
+			launch, err := resolveLaunchCommand(srv)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "skipping %s: %v\n", srv.Name, err)
+				continue
+			}
+			file := filepath.Join(exportOutputFlag, launchdLabelPrefix+srv.Name+".plist")
+			if err := os.WriteFile(file, []byte(serverPlist(srv, launch)), 0o644); err != nil {
+				return err
+			}
+			written = append(written, file)
+		}
+
+		for _, file := range written {
+			fmt.Println(file)
+		}
+		fmt.Printf("\nInstall with:\n  cp %s ~/Library/LaunchAgents/ && launchctl load ~/Library/LaunchAgents/%s*.plist\n",
+			strings.Join(written, " "), launchdLabelPrefix)
+		return nil
+	},
+}
+
+// servePlist renders the LaunchAgent for the devgen registry endpoint.
+func servePlist() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	root, err := findMachinaRoot()
+	if err != nil {
+		return "", err
+	}
+	return renderPlist(launchdLabelPrefix+"serve", []string{exe, "serve"}, root, nil, true,
+		filepath.Join(root, "logs", "devgen-serve.log")), nil
+}
+
+// serverPlist renders a LaunchAgent running one MCP server directly.
+func serverPlist(srv *MCPServer, launch *launchCommand) string {
+	root, _ := findMachinaRoot()
+	logPath := ""
+	if root != "" {
+		logPath = filepath.Join(root, "logs", srv.Name+".log")
+	}
+	args := append([]string{launch.Command}, launch.Args...)
+	keepAlive := restartPolicyFor(srv) != restartNever
+	return renderPlist(launchdLabelPrefix+srv.Name, args, launch.Dir, launch.Env, keepAlive, logPath)
+}
+
+// renderPlist emits a minimal launchd property list.
+func renderPlist(label string, args []string, dir string, env map[string]string, keepAlive bool, logPath string) string {
+	var b strings.Builder
+	b.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	b.WriteString("<!DOCTYPE plist PUBLIC \"-//Apple//DTD PLIST 1.0//EN\" \"http://www.apple.com/DTDs/PropertyList-1.0.dtd\">\n")
+	b.WriteString("<plist version=\"1.0\">\n<dict>\n")
+	fmt.Fprintf(&b, "\t<key>Label</key>\n\t<string>%s</string>\n", xmlEscape(label))
+	b.WriteString("\t<key>ProgramArguments</key>\n\t<array>\n")
+	for _, arg := range args {
+		fmt.Fprintf(&b, "\t\t<string>%s</string>\n", xmlEscape(arg))
+	}
+	b.WriteString("\t</array>\n")
+	if dir != "" {
+		fmt.Fprintf(&b, "\t<key>WorkingDirectory</key>\n\t<string>%s</string>\n", xmlEscape(dir))
+	}
+	if len(env) > 0 {
+		b.WriteString("\t<key>EnvironmentVariables</key>\n\t<dict>\n")
+		keys := make([]string, 0, len(env))
+		for key := range env {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(&b, "\t\t<key>%s</key>\n\t\t<string>%s</string>\n", xmlEscape(key), xmlEscape(env[key]))
+		}
+		b.WriteString("\t</dict>\n")
+	}
+	b.WriteString("\t<key>RunAtLoad</key>\n\t<true/>\n")
+	fmt.Fprintf(&b, "\t<key>KeepAlive</key>\n\t<%t/>\n", keepAlive)
+	if logPath != "" {
+		fmt.Fprintf(&b, "\t<key>StandardOutPath</key>\n\t<string>%s</string>\n", xmlEscape(logPath))
+		fmt.Fprintf(&b, "\t<key>StandardErrorPath</key>\n\t<string>%s</string>\n", xmlEscape(logPath))
+	}
+	b.WriteString("</dict>\n</plist>\n")
+	return b.String()
+}
+
+func xmlEscape(s string) string {
+	replacer := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\"", "&quot;")
+	return replacer.Replace(s)
+}
+
+func init() {
+	exportLaunchdCmd.Flags().StringVar(&exportOutputFlag, "output", ".", "directory to write plists into")
+	exportCmd.AddCommand(exportLaunchdCmd)
+}
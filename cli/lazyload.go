@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// listServerSummaries reads listing data through the daemon when one is
+// running, otherwise via the streaming decoder.
+func listServerSummaries(path string) ([]serverSummary, error) {
+	if reg, err := loadRegistryViaControlPlane(); err == nil {
+		debugf("control plane: registry read via daemon socket")
+		summaries := make([]serverSummary, 0, len(reg.Servers))
+		for _, srv := range reg.Servers {
+			summaries = append(summaries, serverSummary{
+				Name:          srv.Name,
+				Category:      srv.Category,
+				Status:        srv.Status,
+				Version:       srv.Version,
+				Owner:         srv.Owner,
+				Enabled:       srv.Enabled,
+				Tags:          srv.Tags,
+				ToolCount:     len(srv.Tools),
+				ResourceCount: len(srv.Resources),
+				PromptCount:   len(srv.Prompts),
+				Notes:         srv.Notes,
+			})
+		}
+		return summaries, nil
+	}
+	return loadServerSummaries(path)
+}
+
+// serverSummary is the cheap projection of a server record used by listing
+// paths: scalar metadata plus counts, with the tool/resource/prompt arrays
+// never materialized.
+type serverSummary struct {
+	Name          string
+	Category      string
+	Status        string
+	Enabled       bool
+	Version       string
+	Owner         string
+	Tags          map[string]string
+	Notes         []serverNote
+	ToolCount     int
+	ResourceCount int
+	PromptCount   int
+}
+
+// loadServerSummaries streams the registry file with a token decoder,
+// skipping over tool, resource, and prompt arrays while counting their
+// elements. Registries with tens of thousands of tool records list in a
+// fraction of the full-unmarshal cost; commands that need tool names still
+// go through loadMCPRegistry.
+func loadServerSummaries(path string) ([]serverSummary, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, wrapNamed(errRegistryNotFound, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading registry: %w", err)
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(bufio.NewReader(f))
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, fmt.Errorf("parsing registry %s: %w", path, err)
+	}
+	var summaries []serverSummary
+	for dec.More() {
+		key, err := decodeKey(dec)
+		if err != nil {
+			return nil, fmt.Errorf("parsing registry %s: %w", path, err)
+		}
+		if key != "servers" {
+			if err := skipJSONValue(dec); err != nil {
+				return nil, fmt.Errorf("parsing registry %s: %w", path, err)
+			}
+			continue
+		}
+		if err := expectDelim(dec, '['); err != nil {
+			return nil, fmt.Errorf("parsing registry %s: %w", path, err)
+		}
+		for dec.More() {
+			summary, err := decodeServerSummary(dec)
+			if err != nil {
+				return nil, fmt.Errorf("parsing registry %s: %w", path, err)
+			}
+			summaries = append(summaries, summary)
+		}
+		if _, err := dec.Token(); err != nil { // closing ]
+			return nil, fmt.Errorf("parsing registry %s: %w", path, err)
+		}
+	}
+	return summaries, nil
+}
+
+// decodeServerSummary consumes one server object, decoding scalar fields
+// and counting skipped arrays.
+func decodeServerSummary(dec *json.Decoder) (serverSummary, error) {
+	var s serverSummary
+	if err := expectDelim(dec, '{'); err != nil {
+		return s, err
+	}
+	for dec.More() {
+		key, err := decodeKey(dec)
+		if err != nil {
+			return s, err
+		}
+		switch key {
+		case "name":
+			err = dec.Decode(&s.Name)
+		case "category":
+			err = dec.Decode(&s.Category)
+		case "status":
+			err = dec.Decode(&s.Status)
+		case "version":
+			err = dec.Decode(&s.Version)
+		case "owner":
+			err = dec.Decode(&s.Owner)
+		case "enabled":
+			err = dec.Decode(&s.Enabled)
+		case "tags":
+			err = dec.Decode(&s.Tags)
+		case "notes":
+			err = dec.Decode(&s.Notes)
+		case "tools":
+			s.ToolCount, err = countJSONArray(dec)
+		case "resources":
+			s.ResourceCount, err = countJSONArray(dec)
+		case "prompts":
+			s.PromptCount, err = countJSONArray(dec)
+		default:
+			err = skipJSONValue(dec)
+		}
+		if err != nil {
+			return s, err
+		}
+	}
+	_, err := dec.Token() // closing }
+	return s, err
+}
+
+func decodeKey(dec *json.Decoder) (string, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return "", err
+	}
+	key, ok := tok.(string)
+	if !ok {
+		return "", fmt.Errorf("expected object key, got %v", tok)
+	}
+	return key, nil
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("expected %q, got %v", want, tok)
+	}
+	return nil
+}
+
+// countJSONArray consumes an array value, returning its length without
+// retaining elements.
+func countJSONArray(dec *json.Decoder) (int, error) {
+	if err := expectDelim(dec, '['); err != nil {
+		return 0, err
+	}
+	count := 0
+	for dec.More() {
+		if err := skipJSONValue(dec); err != nil {
+			return 0, err
+		}
+		count++
+	}
+	_, err := dec.Token() // closing ]
+	return count, err
+}
+
+// skipJSONValue consumes one value of any shape.
+func skipJSONValue(dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok || (delim != '{' && delim != '[') {
+		return nil
+	}
+	for dec.More() {
+		if delim == '{' {
+			if _, err := decodeKey(dec); err != nil {
+				return err
+			}
+		}
+		if err := skipJSONValue(dec); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token() // closing delimiter
+	return err
+}
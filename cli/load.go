@@ -0,0 +1,232 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// devgen load soak-tests one server: it sustains a fixed request rate
+// against a tool for the duration, then reports the latency distribution,
+// an error taxonomy, and — for servers managed by devgen — resource usage
+// sampled over the run. Nothing is synthesized: every number comes from a
+// real request or a real process sample.
+
+var (
+	loadToolFlag     string
+	loadRPSFlag      float64
+	loadDurationFlag time.Duration
+	loadArgFlags     []string
+	loadJSONFlag     string
+	loadTimeoutFlag  time.Duration
+)
+
+// loadStats accumulates outcomes across worker goroutines.
+type loadStats struct {
+	mu        sync.Mutex
+	latencies []time.Duration
+	errors    map[string]int
+	sent      int
+}
+
+func (s *loadStats) success(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latencies = append(s.latencies, latency)
+}
+
+func (s *loadStats) failure(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors[classifyLoadError(err)]++
+}
+
+// classifyLoadError buckets failures so the report separates server-side
+// errors from timeouts and transport problems.
+func classifyLoadError(err error) string {
+	var rpcErr *rpcError
+	if errors.As(err, &rpcErr) {
+		return fmt.Sprintf("rpc error %d", rpcErr.Code)
+	}
+	if strings.Contains(err.Error(), "timed out") {
+		return "timeout"
+	}
+	if strings.Contains(err.Error(), "closed connection") {
+		return "connection closed"
+	}
+	return "transport: " + err.Error()
+}
+
+// percentile returns the p-th percentile of sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	ix := int(float64(len(sorted)-1) * p)
+	return sorted[ix]
+}
+
+var loadCmd = &cobra.Command{
+	Use:   "load <server>",
+	Short: "Generate sustained load against a server's tool",
+	Long: `Sustains a fixed request rate against one tool for the duration and
+reports what actually happened: latency percentiles, a taxonomy of
+errors, and resource usage for servers managed by devgen. Useful for
+soak-testing a server before giving it real traffic.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if loadToolFlag == "" {
+			return fmt.Errorf("--tool is required")
+		}
+		if loadRPSFlag <= 0 {
+			return fmt.Errorf("--rps must be positive")
+		}
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		srv := reg.findServer(args[0])
+		if srv == nil {
+			return serverNotFoundError(args[0])
+		}
+		callArgs, err := parseCallArgs(loadArgFlags, loadJSONFlag)
+		if err != nil {
+			return err
+		}
+		client, err := dialStdioServer(srv)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		// Resource sampling only works for processes devgen manages; for
+		// anything else the report says so instead of guessing.
+		var managedPID int
+		if state, err := loadManagedState(); err == nil {
+			if proc, ok := state.Servers[srv.Name]; ok && processAlive(proc.PID) {
+				managedPID = proc.PID
+			}
+		}
+
+		stats := &loadStats{errors: map[string]int{}}
+		var wg sync.WaitGroup
+		interval := time.Duration(float64(time.Second) / loadRPSFlag)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		deadline := time.After(loadDurationFlag)
+
+		var samples []procSample
+		var samplesMu sync.Mutex
+		sampleTicker := time.NewTicker(2 * time.Second)
+		defer sampleTicker.Stop()
+
+		fmt.Printf("Load: %s/%s at %.1f req/s for %s\n", srv.Name, loadToolFlag, loadRPSFlag, loadDurationFlag)
+		started := time.Now()
+	loop:
+		for {
+			select {
+			case <-deadline:
+				break loop
+			case <-sampleTicker.C:
+				if managedPID != 0 {
+					if sample, err := sampleProcess(managedPID); err == nil {
+						samplesMu.Lock()
+						samples = append(samples, sample)
+						samplesMu.Unlock()
+					}
+				}
+			case <-ticker.C:
+				stats.sent++
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					begin := time.Now()
+					// Raw call on purpose: load measures failures, and the
+					// outbound retry policy would hide them.
+					_, err := client.call("tools/call", map[string]interface{}{
+						"name":      loadToolFlag,
+						"arguments": callArgs,
+					}, loadTimeoutFlag)
+					if err != nil {
+						stats.failure(err)
+						return
+					}
+					stats.success(time.Since(begin))
+				}()
+			}
+		}
+		wg.Wait()
+		elapsed := time.Since(started)
+
+		stats.mu.Lock()
+		defer stats.mu.Unlock()
+		sort.Slice(stats.latencies, func(a, b int) bool { return stats.latencies[a] < stats.latencies[b] })
+		succeeded := len(stats.latencies)
+		failed := stats.sent - succeeded
+
+		fmt.Printf("\nRequests:  %d sent, %d ok, %d failed (%.2f achieved req/s)\n",
+			stats.sent, succeeded, failed, float64(stats.sent)/elapsed.Seconds())
+		if succeeded > 0 {
+			fmt.Printf("Latency:   p50 %s  p90 %s  p99 %s  max %s\n",
+				percentile(stats.latencies, 0.50).Round(time.Microsecond),
+				percentile(stats.latencies, 0.90).Round(time.Microsecond),
+				percentile(stats.latencies, 0.99).Round(time.Microsecond),
+				stats.latencies[succeeded-1].Round(time.Microsecond))
+		}
+		if failed > 0 {
+			fmt.Println("Errors:")
+			kinds := make([]string, 0, len(stats.errors))
+			for kind := range stats.errors {
+				kinds = append(kinds, kind)
+			}
+			sort.Strings(kinds)
+			for _, kind := range kinds {
+				fmt.Printf("  %6d  %s\n", stats.errors[kind], kind)
+			}
+		}
+
+		samplesMu.Lock()
+		defer samplesMu.Unlock()
+		if managedPID == 0 {
+			fmt.Println("Resources: server is not managed by devgen; usage not sampled")
+		} else if len(samples) == 0 {
+			fmt.Println("Resources: no samples collected")
+		} else {
+			var cpuSum, cpuMax, rssMax float64
+			for _, sample := range samples {
+				cpuSum += sample.CPUPercent
+				if sample.CPUPercent > cpuMax {
+					cpuMax = sample.CPUPercent
+				}
+				if sample.RSSMegabytes > rssMax {
+					rssMax = sample.RSSMegabytes
+				}
+			}
+			fmt.Printf("Resources: cpu avg %.1f%% max %.1f%% | rss max %.1fM (%d samples)\n",
+				cpuSum/float64(len(samples)), cpuMax, rssMax, len(samples))
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d requests failed", failed, stats.sent)
+		}
+		return nil
+	},
+}
+
+func init() {
+	loadCmd.Flags().StringVar(&loadToolFlag, "tool", "", "tool to invoke (required)")
+	loadCmd.Flags().Float64Var(&loadRPSFlag, "rps", 5, "sustained requests per second")
+	loadCmd.Flags().DurationVar(&loadDurationFlag, "duration", 30*time.Second, "how long to run")
+	loadCmd.Flags().StringArrayVar(&loadArgFlags, "arg", nil, "tool argument as key=value (repeatable)")
+	loadCmd.Flags().StringVar(&loadJSONFlag, "json", "", "tool arguments as a JSON object")
+	loadCmd.Flags().DurationVar(&loadTimeoutFlag, "timeout", 30*time.Second, "per-request timeout")
+	rootCmd.AddCommand(loadCmd)
+}
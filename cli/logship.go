@@ -0,0 +1,268 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Log shipping forwards captured stdout/stderr of managed servers to an
+// OTLP/HTTP logs endpoint (Logfire speaks OTLP). The daemon tails each
+// shipped server's log file between sweeps and posts new lines with the
+// server name and category as attributes; per-file offsets persist next to
+// the registry so restarts don't re-ship old output.
+
+// logShipOffsetsFileName remembers how far each log file has been shipped.
+const logShipOffsetsFileName = "log_ship_offsets.json"
+
+// logShipMaxBatch caps lines per POST so a chatty server can't build an
+// unbounded payload.
+const logShipMaxBatch = 512
+
+// logShippingConfig points at the OTLP/HTTP logs endpoint, e.g.
+// https://logfire-api.pydantic.dev/v1/logs, with auth headers.
+type logShippingConfig struct {
+	Endpoint string            `json:"endpoint,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"`
+}
+
+type logShipOffsets struct {
+	Offsets map[string]int64 `json:"offsets"`
+}
+
+func logShipOffsetsPath() (string, error) {
+	path, err := registryPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), logShipOffsetsFileName), nil
+}
+
+func loadLogShipOffsets() *logShipOffsets {
+	offsets := &logShipOffsets{Offsets: map[string]int64{}}
+	path, err := logShipOffsetsPath()
+	if err != nil {
+		return offsets
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return offsets
+	}
+	json.Unmarshal(data, offsets)
+	if offsets.Offsets == nil {
+		offsets.Offsets = map[string]int64{}
+	}
+	return offsets
+}
+
+func saveLogShipOffsets(offsets *logShipOffsets) {
+	path, err := logShipOffsetsPath()
+	if err != nil {
+		return
+	}
+	data, err := json.MarshalIndent(offsets, "", "  ")
+	if err != nil {
+		return
+	}
+	os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// shipLogsSweep tails and ships new log output for every server with
+// ship_logs enabled. Failed posts keep their offset and retry next sweep.
+func shipLogsSweep(reg *MCPRegistry, logger *daemonLogger) {
+	cfg, err := loadConfig()
+	if err != nil || cfg.Logs.Endpoint == "" {
+		return
+	}
+	root, err := findMachinaRoot()
+	if err != nil {
+		return
+	}
+	offsets := loadLogShipOffsets()
+	changed := false
+	for i := range reg.Servers {
+		srv := &reg.Servers[i]
+		if !srv.ShipLogs {
+			continue
+		}
+		logPath := filepath.Join(root, "logs", srv.Name+".log")
+		lines, next, err := readLogLinesFrom(logPath, offsets.Offsets[srv.Name])
+		if err != nil || len(lines) == 0 {
+			continue
+		}
+		if err := postOTLPLogs(cfg.Logs, srv, lines); err != nil {
+			logger.log("logship_error", srv.Name, map[string]string{"error": err.Error()})
+			continue
+		}
+		offsets.Offsets[srv.Name] = next
+		changed = true
+	}
+	if changed {
+		saveLogShipOffsets(offsets)
+	}
+}
+
+// readLogLinesFrom returns complete lines after the byte offset and the
+// offset consumed up to, capped at logShipMaxBatch lines. A truncated file
+// (rotation) restarts from the beginning.
+func readLogLinesFrom(path string, offset int64) ([]string, int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, offset, err
+	}
+	if info.Size() < offset {
+		offset = 0
+	}
+	if info.Size() == offset {
+		return nil, offset, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, offset, err
+	}
+	defer f.Close()
+	if _, err := f.Seek(offset, 0); err != nil {
+		return nil, offset, err
+	}
+	data := make([]byte, info.Size()-offset)
+	n, err := f.Read(data)
+	if err != nil {
+		return nil, offset, err
+	}
+	data = data[:n]
+
+	var lines []string
+	consumed := int64(0)
+	for {
+		idx := bytes.IndexByte(data[consumed:], '\n')
+		if idx < 0 || len(lines) >= logShipMaxBatch {
+			break
+		}
+		lines = append(lines, string(data[consumed:consumed+int64(idx)]))
+		consumed += int64(idx) + 1
+	}
+	return lines, offset + consumed, nil
+}
+
+// postOTLPLogs sends one batch of lines as an OTLP/HTTP JSON logs request.
+func postOTLPLogs(cfg logShippingConfig, srv *MCPServer, lines []string) error {
+	type kv struct {
+		Key   string `json:"key"`
+		Value struct {
+			StringValue string `json:"stringValue"`
+		} `json:"value"`
+	}
+	attr := func(key, value string) kv {
+		var a kv
+		a.Key = key
+		a.Value.StringValue = value
+		return a
+	}
+	type logRecord struct {
+		TimeUnixNano string `json:"timeUnixNano"`
+		Body         struct {
+			StringValue string `json:"stringValue"`
+		} `json:"body"`
+		Attributes []kv `json:"attributes"`
+	}
+	now := fmt.Sprintf("%d", time.Now().UnixNano())
+	records := make([]logRecord, 0, len(lines))
+	for _, line := range lines {
+		var rec logRecord
+		rec.TimeUnixNano = now
+		rec.Body.StringValue = line
+		rec.Attributes = []kv{attr("mcp.server", srv.Name), attr("mcp.category", srv.Category)}
+		records = append(records, rec)
+	}
+	payload := map[string]interface{}{
+		"resourceLogs": []map[string]interface{}{{
+			"resource": map[string]interface{}{
+				"attributes": []kv{attr("service.name", "machina/"+srv.Name)},
+			},
+			"scopeLogs": []map[string]interface{}{{
+				"scope":      map[string]string{"name": "devgen"},
+				"logRecords": records,
+			}},
+		}},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range cfg.Headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := outboundHTTPClient(10 * time.Second).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("endpoint returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+var logshipCmd = &cobra.Command{
+	Use:   "logship <server> on|off",
+	Short: "Toggle shipping a managed server's output to the logs endpoint",
+	Long: `Enables or disables forwarding of a managed server's captured
+stdout/stderr to the OTLP/HTTP endpoint configured under the logs config
+key. The daemon ships new output between supervise sweeps; nothing is
+shipped while no daemon runs.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var enable bool
+		switch args[1] {
+		case "on":
+			enable = true
+		case "off":
+		default:
+			return fmt.Errorf("logship takes on or off, got %q", args[1])
+		}
+		if enable {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			if cfg.Logs.Endpoint == "" {
+				return fmt.Errorf("no logs endpoint configured; set it with 'devgen config set logs.endpoint <url>'")
+			}
+			if !strings.HasPrefix(cfg.Logs.Endpoint, "http://") && !strings.HasPrefix(cfg.Logs.Endpoint, "https://") {
+				return fmt.Errorf("logs endpoint %q is not an http(s) URL", cfg.Logs.Endpoint)
+			}
+		}
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		if err := mutateServer(path, args[0], func(srv *MCPServer) error {
+			srv.ShipLogs = enable
+			return nil
+		}); err != nil {
+			return err
+		}
+		state := "disabled"
+		if enable {
+			state = "enabled"
+		}
+		fmt.Printf("Log shipping %s for %s\n", state, args[0])
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(logshipCmd)
+}
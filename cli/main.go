@@ -0,0 +1,88 @@
+// DevGen CLI - MCP server management for the Machina registry.
+//
+// DevGen is the terminal companion to the Machina MCP Registry Platform.
+// It reads the registry file (mcp_status.json) produced by registry/main.py
+// and provides commands for listing, toggling, proxying, and inspecting
+// the registered MCP servers.
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const devgenVersion = "0.1.0"
+
+var (
+	// registryFlag overrides the discovered registry path.
+	registryFlag string
+	// verboseFlag enables debug logging.
+	verboseFlag bool
+	// noColorFlag disables all color output.
+	noColorFlag bool
+	// colorFlag forces a specific color profile; see parseColorProfile.
+	colorFlag string
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "devgen",
+	Short: "DevGen CLI - manage Machina MCP servers",
+	Long: `DevGen is the Machina MCP Registry command line tool.
+
+It manages the MCP servers registered in mcp_status.json: listing,
+toggling, health checking, proxying, and inspecting traffic.`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Version:       devgenVersion,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return rootPreRun(cmd)
+	},
+}
+
+func main() {
+	// Git-style extension point: an unknown first argument with a matching
+	// devgen-<name> executable on PATH runs that plugin; see plugin.go.
+	if name := pluginCandidate(os.Args[1:]); name != "" {
+		if exe, ok := findPlugin(name); ok {
+			os.Exit(runPlugin(exe, os.Args[2:]))
+		}
+	}
+	err := rootCmd.Execute()
+	// Give published state-change events time to reach their sinks before
+	// the process exits; see eventbus.go.
+	drainEventBus(5 * time.Second)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "devgen: %v\n", err)
+		os.Exit(exitCodeFor(err))
+	}
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&registryFlag, "registry", "", "path to mcp_status.json (default: auto-discover)")
+	rootCmd.PersistentFlags().BoolVarP(&verboseFlag, "verbose", "v", false, "enable debug logging")
+	rootCmd.PersistentFlags().BoolVar(&noColorFlag, "no-color", false, "disable color output (NO_COLOR is also honored)")
+	rootCmd.PersistentFlags().StringVar(&colorFlag, "color", "auto",
+		"force the color profile for odd terminals: auto, truecolor, 256, 16, or ascii")
+	rootCmd.PersistentFlags().StringVar(&debugDirFlag, "debug-dir", "", "directory for diagnostic output (default: $XDG_STATE_HOME/devgen)")
+}
+
+// debugf appends a debug line to debug.log in the state directory when
+// --verbose is set.
+func debugf(format string, args ...interface{}) {
+	if !verboseFlag {
+		return
+	}
+	path, err := stateFilePath("debug.log")
+	if err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	fmt.Fprintf(f, format+"\n", args...)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// statusMaintenance marks a server an operator is deliberately working on:
+// health alerts are suppressed, and the server is excluded from proxy
+// routing and default export selection until maintenance ends.
+const statusMaintenance = "maintenance"
+
+// inMaintenance reports whether a server is currently under maintenance. A
+// maintenance window with a passed --until deadline no longer counts, even
+// before anything clears the status field.
+func inMaintenance(srv *MCPServer) bool {
+	if srv.Status != statusMaintenance {
+		return false
+	}
+	if srv.MaintenanceUntil == "" {
+		return true
+	}
+	until, err := time.Parse(time.RFC3339, srv.MaintenanceUntil)
+	if err != nil {
+		return true
+	}
+	return time.Now().Before(until)
+}
+
+var maintenanceUntilFlag time.Duration
+
+var maintenanceCmd = &cobra.Command{
+	Use:   "maintenance <server> on|off",
+	Short: "Put a server into or take it out of maintenance mode",
+	Long: `Maintenance mode suppresses health alerts and excludes the server from
+proxy routing and default export selection. With --until, maintenance
+expires automatically after the given duration; otherwise it lasts until
+turned off.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		name := args[0]
+		switch args[1] {
+		case "on":
+			var until string
+			if maintenanceUntilFlag > 0 {
+				until = time.Now().Add(maintenanceUntilFlag).UTC().Format(time.RFC3339)
+			}
+			err := mutateServer(path, name, func(srv *MCPServer) error {
+				if srv.Status == statusQuarantined {
+					return fmt.Errorf("%s is quarantined; run 'devgen start %s' to release it first", name, name)
+				}
+				srv.Status = statusMaintenance
+				srv.MaintenanceUntil = until
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if until != "" {
+				fmt.Printf("%s is in maintenance until %s\n", name, until)
+			} else {
+				fmt.Printf("%s is in maintenance until turned off\n", name)
+			}
+			return nil
+		case "off":
+			err := mutateServer(path, name, func(srv *MCPServer) error {
+				if srv.Status != statusMaintenance {
+					return fmt.Errorf("%s is not in maintenance (status %s)", name, srv.Status)
+				}
+				srv.Status = "active"
+				srv.MaintenanceUntil = ""
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s is out of maintenance\n", name)
+			return nil
+		default:
+			return fmt.Errorf("maintenance takes on or off, got %q", args[1])
+		}
+	},
+}
+
+func init() {
+	maintenanceCmd.Flags().DurationVar(&maintenanceUntilFlag, "until", 0,
+		"end maintenance automatically after this duration, e.g. 2h")
+	rootCmd.AddCommand(maintenanceCmd)
+}
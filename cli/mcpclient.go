@@ -0,0 +1,283 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// mcpProtocolVersion is the MCP protocol revision devgen speaks.
+const mcpProtocolVersion = "2024-11-05"
+
+// mcpClient is a minimal JSON-RPC 2.0 client for stdio MCP servers. It owns
+// the child process and pairs responses to requests by id.
+type mcpClient struct {
+	cmd     *exec.Cmd
+	stdin   *json.Encoder
+	stdinW  *bufio.Writer
+	nextID  atomic.Int64
+	mu      sync.Mutex
+	pending map[int64]chan *rpcResponse
+	done    chan struct{}
+
+	// initResult holds the server's initialize response for capability
+	// reporting.
+	initResult *initializeResult
+}
+
+// initializeResult is the server half of the MCP initialize handshake.
+type initializeResult struct {
+	ProtocolVersion string                     `json:"protocolVersion"`
+	Capabilities    map[string]json.RawMessage `json:"capabilities"`
+	ServerInfo      struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"serverInfo"`
+}
+
+type rpcRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      int64       `json:"id"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+type rpcNotification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+// dialStdioServer launches the server's stdio command and completes the MCP
+// initialize handshake, returning a ready client.
+func dialStdioServer(srv *MCPServer) (*mcpClient, error) {
+	launch, err := resolveLaunchCommand(srv)
+	if err != nil {
+		return nil, err
+	}
+	return dialStdioCommand(launch)
+}
+
+// dialStdioCommand starts a stdio MCP server from a resolved launch command
+// and performs the initialize handshake.
+func dialStdioCommand(launch *launchCommand) (*mcpClient, error) {
+	cmd := exec.Command(launch.Command, launch.Args...)
+	cmd.Dir = launch.Dir
+	cmd.Env = launchEnviron(launch)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting server: %w", err)
+	}
+
+	writer := bufio.NewWriter(stdin)
+	client := &mcpClient{
+		cmd:     cmd,
+		stdin:   json.NewEncoder(writer),
+		stdinW:  writer,
+		pending: map[int64]chan *rpcResponse{},
+		done:    make(chan struct{}),
+	}
+
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			var resp rpcResponse
+			if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil || resp.ID == nil {
+				continue // notification or noise
+			}
+			var id int64
+			if err := json.Unmarshal(resp.ID, &id); err != nil {
+				continue
+			}
+			client.mu.Lock()
+			ch, ok := client.pending[id]
+			if ok {
+				delete(client.pending, id)
+			}
+			client.mu.Unlock()
+			if ok {
+				ch <- &resp
+			}
+		}
+		close(client.done)
+	}()
+
+	result, err := client.call("initialize", map[string]interface{}{
+		"protocolVersion": mcpProtocolVersion,
+		"capabilities":    map[string]interface{}{},
+		"clientInfo": map[string]interface{}{
+			"name":    "devgen",
+			"version": devgenVersion,
+		},
+	}, 10*time.Second)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("initialize handshake failed: %w", err)
+	}
+	var initRes initializeResult
+	if err := json.Unmarshal(result, &initRes); err == nil {
+		client.initResult = &initRes
+	}
+	client.notify("notifications/initialized", nil)
+	return client, nil
+}
+
+// call sends a request and waits for its response or timeout.
+func (c *mcpClient) call(method string, params interface{}, timeout time.Duration) (json.RawMessage, error) {
+	id := c.nextID.Add(1)
+	ch := make(chan *rpcResponse, 1)
+	c.mu.Lock()
+	c.pending[id] = ch
+	if err := c.stdin.Encode(rpcRequest{JSONRPC: "2.0", ID: id, Method: method, Params: params}); err != nil {
+		delete(c.pending, id)
+		c.mu.Unlock()
+		return nil, err
+	}
+	c.stdinW.Flush()
+	c.mu.Unlock()
+
+	select {
+	case resp := <-ch:
+		if resp.Error != nil {
+			return nil, resp.Error
+		}
+		return resp.Result, nil
+	case <-c.done:
+		return nil, fmt.Errorf("server closed connection during %s", method)
+	case <-time.After(timeout):
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+		return nil, fmt.Errorf("%s timed out after %s", method, timeout)
+	}
+}
+
+// callWithRetry runs call under the configured outbound retry policy.
+// Only transport-level failures (timeouts, closed pipes) are retried;
+// an rpc error is the server's answer and is returned as-is.
+func (c *mcpClient) callWithRetry(method string, params interface{}, timeout time.Duration) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := outboundRetryPolicy().do(func() (bool, error) {
+		var err error
+		result, err = c.call(method, params, timeout)
+		if err == nil {
+			return false, nil
+		}
+		var rpcErr *rpcError
+		return !errors.As(err, &rpcErr), err
+	})
+	return result, err
+}
+
+// notify sends a notification (no response expected).
+func (c *mcpClient) notify(method string, params interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stdin.Encode(rpcNotification{JSONRPC: "2.0", Method: method, Params: params})
+	c.stdinW.Flush()
+}
+
+// Close terminates the child process.
+func (c *mcpClient) Close() error {
+	if c.cmd.Process != nil {
+		c.cmd.Process.Kill()
+	}
+	return c.cmd.Wait()
+}
+
+// toolInfo, resourceInfo, and promptInfo mirror the MCP list result entries.
+type toolInfo struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	InputSchema json.RawMessage `json:"inputSchema,omitempty"`
+}
+
+type resourceInfo struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+type promptInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// listTools enumerates the server's tools via tools/list.
+func (c *mcpClient) listTools() ([]toolInfo, error) {
+	result, err := c.call("tools/list", map[string]interface{}{}, 15*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	var parsed struct {
+		Tools []toolInfo `json:"tools"`
+	}
+	if err := json.Unmarshal(result, &parsed); err != nil {
+		return nil, err
+	}
+	return parsed.Tools, nil
+}
+
+// listResources enumerates resources via resources/list. Servers without
+// resource support return a method-not-found error, reported as-is.
+func (c *mcpClient) listResources() ([]resourceInfo, error) {
+	result, err := c.call("resources/list", map[string]interface{}{}, 15*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	var parsed struct {
+		Resources []resourceInfo `json:"resources"`
+	}
+	if err := json.Unmarshal(result, &parsed); err != nil {
+		return nil, err
+	}
+	return parsed.Resources, nil
+}
+
+// listPrompts enumerates prompts via prompts/list.
+func (c *mcpClient) listPrompts() ([]promptInfo, error) {
+	result, err := c.call("prompts/list", map[string]interface{}{}, 15*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	var parsed struct {
+		Prompts []promptInfo `json:"prompts"`
+	}
+	if err := json.Unmarshal(result, &parsed); err != nil {
+		return nil, err
+	}
+	return parsed.Prompts, nil
+}
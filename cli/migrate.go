@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// registrySchemaVersion is the schema this build reads and writes.
+const registrySchemaVersion = "2"
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade the registry file to the current schema",
+	Long: `Rewrites old registry formats to the current schema: the legacy
+mcp_servers map becomes the servers list, per-server "url" and "active"
+fields are renamed to "endpoint" and "enabled", and missing defaults are
+filled in. The original file is kept beside the registry as <name>.bak.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		original, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return wrapNamed(errRegistryNotFound, path)
+			}
+			return err
+		}
+		var raw map[string]interface{}
+		if err := json.Unmarshal(original, &raw); err != nil {
+			return fmt.Errorf("parsing registry %s: %w", path, err)
+		}
+		changes := migrateRegistryDocument(raw)
+		if len(changes) == 0 {
+			fmt.Printf("Registry already at schema %s; nothing to migrate.\n", registrySchemaVersion)
+			return nil
+		}
+		data, err := json.MarshalIndent(raw, "", "  ")
+		if err != nil {
+			return err
+		}
+		if dryRunFlag {
+			printDryRunDiff(path, string(original), string(data))
+			return nil
+		}
+		if err := os.WriteFile(path+".bak", original, 0o644); err != nil {
+			return fmt.Errorf("writing backup: %w", err)
+		}
+		if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+			return err
+		}
+		for _, change := range changes {
+			fmt.Println("  " + change)
+		}
+		fmt.Printf("Migrated %s to schema %s (original saved as %s.bak)\n", path, registrySchemaVersion, path)
+		return nil
+	},
+}
+
+// migrateRegistryDocument upgrades a raw registry document in place and
+// returns a description of each change applied.
+func migrateRegistryDocument(raw map[string]interface{}) []string {
+	var changes []string
+
+	// Legacy layout: servers keyed by name under "mcp_servers".
+	if legacy, ok := raw["mcp_servers"].(map[string]interface{}); ok {
+		var servers []interface{}
+		for _, name := range sortedKeys(legacy) {
+			srv, ok := legacy[name].(map[string]interface{})
+			if !ok {
+				srv = map[string]interface{}{}
+			}
+			srv["name"] = name
+			servers = append(servers, srv)
+		}
+		raw["servers"] = servers
+		delete(raw, "mcp_servers")
+		changes = append(changes, fmt.Sprintf("converted mcp_servers map to servers list (%d entries)", len(servers)))
+	}
+
+	servers, _ := raw["servers"].([]interface{})
+	renamedURL, renamedActive, filledEnabled := 0, 0, 0
+	for _, entry := range servers {
+		srv, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if url, ok := srv["url"]; ok {
+			if _, exists := srv["endpoint"]; !exists {
+				srv["endpoint"] = url
+			}
+			delete(srv, "url")
+			renamedURL++
+		}
+		if active, ok := srv["active"].(bool); ok {
+			if _, exists := srv["enabled"]; !exists {
+				srv["enabled"] = active
+			}
+			delete(srv, "active")
+			renamedActive++
+		}
+		if _, ok := srv["enabled"]; !ok {
+			srv["enabled"] = true
+			filledEnabled++
+		}
+	}
+	if renamedURL > 0 {
+		changes = append(changes, fmt.Sprintf("renamed url to endpoint on %d server(s)", renamedURL))
+	}
+	if renamedActive > 0 {
+		changes = append(changes, fmt.Sprintf("renamed active to enabled on %d server(s)", renamedActive))
+	}
+	if filledEnabled > 0 {
+		changes = append(changes, fmt.Sprintf("defaulted enabled=true on %d server(s)", filledEnabled))
+	}
+
+	if version, _ := raw["version"].(string); version != registrySchemaVersion {
+		raw["version"] = registrySchemaVersion
+		changes = append(changes, fmt.Sprintf("set version to %s", registrySchemaVersion))
+	}
+	return changes
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// mockTool is one entry in the --tools file: a tool definition plus the
+// canned result returned for any call to it.
+type mockTool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	InputSchema json.RawMessage `json:"inputSchema,omitempty"`
+	Response    json.RawMessage `json:"response,omitempty"`
+}
+
+var (
+	mockToolsFlag string
+	mockPortFlag  int
+	mockNameFlag  string
+)
+
+var mockCmd = &cobra.Command{
+	Use:   "mock",
+	Short: "Serve a fake MCP server with canned responses",
+	Long: `Serves an MCP-over-HTTP server whose tools and responses come from a
+JSON file, so client integrations and dashboards can be developed without
+real backends. The tools file is an array of objects with name, description,
+inputSchema, and response fields; the response is returned verbatim as the
+tool call result.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tools, err := loadMockTools(mockToolsFlag)
+		if err != nil {
+			return err
+		}
+		addr := fmt.Sprintf(":%d", mockPortFlag)
+		fmt.Printf("Mock MCP server %q listening on http://localhost%s with %d tools\n",
+			mockNameFlag, addr, len(tools))
+		return http.ListenAndServe(addr, mockHandler(mockNameFlag, tools))
+	},
+}
+
+func init() {
+	mockCmd.Flags().StringVar(&mockToolsFlag, "tools", "", "path to tools JSON file (required)")
+	mockCmd.Flags().IntVar(&mockPortFlag, "port", 9000, "port to listen on")
+	mockCmd.Flags().StringVar(&mockNameFlag, "name", "devgen-mock", "server name reported in initialize")
+	mockCmd.MarkFlagRequired("tools")
+	rootCmd.AddCommand(mockCmd)
+}
+
+func loadMockTools(path string) ([]mockTool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading tools file: %w", err)
+	}
+	var tools []mockTool
+	if err := json.Unmarshal(data, &tools); err != nil {
+		return nil, fmt.Errorf("parsing tools file %s: %w", path, err)
+	}
+	for _, tool := range tools {
+		if tool.Name == "" {
+			return nil, fmt.Errorf("tools file %s contains a tool without a name", path)
+		}
+	}
+	return tools, nil
+}
+
+// mockHandler implements just enough JSON-RPC-over-HTTP to satisfy MCP
+// clients: initialize, tools/list, and tools/call.
+func mockHandler(name string, tools []mockTool) http.Handler {
+	byName := map[string]mockTool{}
+	for _, tool := range tools {
+		byName[tool.Name] = tool
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "POST JSON-RPC requests only", http.StatusMethodNotAllowed)
+			return
+		}
+		var req struct {
+			JSONRPC string          `json:"jsonrpc"`
+			ID      json.RawMessage `json:"id"`
+			Method  string          `json:"method"`
+			Params  struct {
+				Name      string          `json:"name"`
+				Arguments json.RawMessage `json:"arguments"`
+			} `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON-RPC request", http.StatusBadRequest)
+			return
+		}
+
+		reply := func(result interface{}) {
+			writeRPCResult(w, req.ID, result)
+		}
+		switch req.Method {
+		case "initialize":
+			reply(map[string]interface{}{
+				"protocolVersion": mcpProtocolVersion,
+				"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+				"serverInfo":      map[string]string{"name": name, "version": devgenVersion},
+			})
+		case "notifications/initialized":
+			w.WriteHeader(http.StatusAccepted)
+		case "tools/list":
+			reply(map[string]interface{}{"tools": tools})
+		case "tools/call":
+			tool, ok := byName[req.Params.Name]
+			if !ok {
+				writeRPCError(w, req.ID, -32602, fmt.Sprintf("unknown tool: %s", req.Params.Name))
+				return
+			}
+			response := tool.Response
+			if response == nil {
+				response = json.RawMessage(`{"content":[{"type":"text","text":"mock response"}]}`)
+			}
+			reply(response)
+		case "resources/list", "prompts/list":
+			writeRPCError(w, req.ID, -32601, "method not found")
+		default:
+			writeRPCError(w, req.ID, -32601, fmt.Sprintf("method not found: %s", req.Method))
+		}
+	})
+}
+
+func writeRPCResult(w http.ResponseWriter, id json.RawMessage, result interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"result":  result,
+	})
+}
+
+func writeRPCError(w http.ResponseWriter, id json.RawMessage, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"error":   map[string]interface{}{"code": code, "message": message},
+	})
+}
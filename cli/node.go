@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+)
+
+// applyNodeEnvironment rewrites a launch command for npm-packaged servers so
+// they run through npx with a pinned version, failing clearly when Node is
+// not installed.
+func applyNodeEnvironment(srv *MCPServer, launch *launchCommand) error {
+	spec := srv.Launch
+	if spec == nil || spec.NpmPackage == "" {
+		return nil
+	}
+	if _, err := exec.LookPath("npx"); err != nil {
+		return fmt.Errorf(
+			"server %s: launching %s requires Node.js (npx not found; install Node 18+ from https://nodejs.org)",
+			srv.Name, spec.NpmPackage)
+	}
+	launch.Args = append([]string{"-y", npmPackageSpec(spec)}, launch.Args...)
+	launch.Command = "npx"
+	return nil
+}
+
+// npmPackageSpec renders the pinned package spec, e.g. @scope/server@1.2.3.
+func npmPackageSpec(spec *LaunchSpec) string {
+	if spec.NpmVersion != "" {
+		return spec.NpmPackage + "@" + spec.NpmVersion
+	}
+	return spec.NpmPackage
+}
+
+var prefetchCmd = &cobra.Command{
+	Use:   "prefetch",
+	Short: "Warm the npm cache for Node-based servers",
+	Long: `Downloads every npm-packaged server into the local npm cache so the
+first 'npx' launch doesn't pay the network fetch cost (or fail offline).`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		if _, err := exec.LookPath("npm"); err != nil {
+			return fmt.Errorf("npm not found; install Node.js 18+ to prefetch npm-packaged servers")
+		}
+
+		prefetched := 0
+		for i := range reg.Servers {
+			srv := &reg.Servers[i]
+			if srv.Launch == nil || srv.Launch.NpmPackage == "" {
+				continue
+			}
+			pkg := npmPackageSpec(srv.Launch)
+			fmt.Printf("Prefetching %s (%s)...\n", srv.Name, pkg)
+			out, err := exec.Command("npm", "cache", "add", pkg).CombinedOutput()
+			if err != nil {
+				return fmt.Errorf("prefetching %s: %v\n%s", pkg, err, out)
+			}
+			prefetched++
+		}
+		if prefetched == 0 {
+			fmt.Println("No npm-packaged servers in the registry.")
+		} else {
+			fmt.Printf("Prefetched %d package(s).\n", prefetched)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(prefetchCmd)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// ownerLine formats a server's ownership for alerts and notifications,
+// e.g. "owned by platform-team (page #mcp-oncall)", or "" when no owner
+// is recorded.
+func ownerLine(srv *MCPServer) string {
+	switch {
+	case srv.Owner != "" && srv.Contact != "":
+		return fmt.Sprintf("owned by %s (page %s)", srv.Owner, srv.Contact)
+	case srv.Owner != "":
+		return fmt.Sprintf("owned by %s", srv.Owner)
+	case srv.Contact != "":
+		return fmt.Sprintf("page %s", srv.Contact)
+	}
+	return ""
+}
+
+var (
+	whoOwnerFlag   string
+	whoContactFlag string
+)
+
+var whoCmd = &cobra.Command{
+	Use:   "who <server>",
+	Short: "Show or set who owns a server",
+	Long: `Prints the owner and contact recorded for a server, so on-call
+engineers know whom to page. --owner and --contact update the fields;
+owner names a team or person, contact is how to reach them (a Slack
+channel, pager alias, or email).`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		if cmd.Flags().Changed("owner") || cmd.Flags().Changed("contact") {
+			return mutateServer(path, args[0], func(srv *MCPServer) error {
+				if cmd.Flags().Changed("owner") {
+					srv.Owner = whoOwnerFlag
+				}
+				if cmd.Flags().Changed("contact") {
+					srv.Contact = whoContactFlag
+				}
+				return nil
+			})
+		}
+		reg, err := loadRegistryPreferDaemon(path)
+		if err != nil {
+			return err
+		}
+		srv := reg.findServer(args[0])
+		if srv == nil {
+			return serverNotFoundError(args[0])
+		}
+		if srv.Owner == "" && srv.Contact == "" {
+			fmt.Printf("%s has no owner recorded; set one with 'devgen who %s --owner <team> --contact <channel>'\n",
+				srv.Name, srv.Name)
+			return nil
+		}
+		fmt.Printf("Server:  %s\n", srv.Name)
+		if srv.Owner != "" {
+			fmt.Printf("Owner:   %s\n", srv.Owner)
+		}
+		if srv.Contact != "" {
+			fmt.Printf("Contact: %s\n", srv.Contact)
+		}
+		return nil
+	},
+}
+
+func init() {
+	whoCmd.Flags().StringVar(&whoOwnerFlag, "owner", "", "team or person owning the server")
+	whoCmd.Flags().StringVar(&whoContactFlag, "contact", "", "how to reach the owner (channel, pager, email)")
+	rootCmd.AddCommand(whoCmd)
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// A pipeline chains tool calls across servers: each step's decoded result
+// is exposed to later steps through ${step.path.to[0].value} placeholders,
+// so scripted multi-server workflows need no client code. Example flow:
+//
+//	name: smoke
+//	steps:
+//	  - name: fetch
+//	    server: context7
+//	    tool: search
+//	    args:
+//	      query: machina
+//	  - name: store
+//	    server: surrealdb
+//	    tool: insert
+//	    args:
+//	      text: ${fetch.content[0].text}
+
+type pipelineSpec struct {
+	Name  string         `yaml:"name"`
+	Steps []pipelineStep `yaml:"steps"`
+}
+
+type pipelineStep struct {
+	Name   string                 `yaml:"name"`
+	Server string                 `yaml:"server"`
+	Tool   string                 `yaml:"tool"`
+	Args   map[string]interface{} `yaml:"args"`
+}
+
+func loadPipeline(path string) (*pipelineSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var spec pipelineSpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if len(spec.Steps) == 0 {
+		return nil, fmt.Errorf("%s defines no steps", path)
+	}
+	seen := map[string]bool{}
+	for i, step := range spec.Steps {
+		if step.Name == "" {
+			return nil, fmt.Errorf("step %d has no name", i+1)
+		}
+		if seen[step.Name] {
+			return nil, fmt.Errorf("duplicate step name %q", step.Name)
+		}
+		seen[step.Name] = true
+		if step.Server == "" || step.Tool == "" {
+			return nil, fmt.Errorf("step %q needs both server and tool", step.Name)
+		}
+	}
+	return &spec, nil
+}
+
+// placeholderPattern matches ${step.path} references inside argument
+// values.
+var placeholderPattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// resolveStepArgs substitutes placeholders in one step's arguments using
+// the results of completed steps. A value that is exactly one placeholder
+// keeps the referenced value's type; placeholders embedded in a longer
+// string are stringified in place.
+func resolveStepArgs(args map[string]interface{}, results map[string]interface{}) (map[string]interface{}, error) {
+	resolved := map[string]interface{}{}
+	for key, value := range args {
+		out, err := resolveValue(value, results)
+		if err != nil {
+			return nil, fmt.Errorf("arg %s: %w", key, err)
+		}
+		resolved[key] = out
+	}
+	return resolved, nil
+}
+
+func resolveValue(value interface{}, results map[string]interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		if match := placeholderPattern.FindStringSubmatch(v); match != nil && match[0] == v {
+			return lookupPath(match[1], results)
+		}
+		var resolveErr error
+		out := placeholderPattern.ReplaceAllStringFunc(v, func(placeholder string) string {
+			path := placeholder[2 : len(placeholder)-1]
+			found, err := lookupPath(path, results)
+			if err != nil {
+				resolveErr = err
+				return placeholder
+			}
+			if s, ok := found.(string); ok {
+				return s
+			}
+			data, err := json.Marshal(found)
+			if err != nil {
+				resolveErr = err
+				return placeholder
+			}
+			return string(data)
+		})
+		return out, resolveErr
+	case map[string]interface{}:
+		resolved := map[string]interface{}{}
+		for key, inner := range v {
+			out, err := resolveValue(inner, results)
+			if err != nil {
+				return nil, err
+			}
+			resolved[key] = out
+		}
+		return resolved, nil
+	case []interface{}:
+		resolved := make([]interface{}, len(v))
+		for i, inner := range v {
+			out, err := resolveValue(inner, results)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = out
+		}
+		return resolved, nil
+	}
+	return value, nil
+}
+
+// lookupPath resolves "step.field.sub[2].name" against completed step
+// results. Missing references fail the pipeline rather than passing empty
+// strings downstream.
+func lookupPath(path string, results map[string]interface{}) (interface{}, error) {
+	segments := strings.Split(path, ".")
+	step := segments[0]
+	current, ok := results[step]
+	if !ok {
+		return nil, fmt.Errorf("reference %q: no completed step named %q", path, step)
+	}
+	for _, segment := range segments[1:] {
+		field := segment
+		var indexes []int
+		for strings.HasSuffix(field, "]") {
+			open := strings.LastIndex(field, "[")
+			if open < 0 {
+				return nil, fmt.Errorf("reference %q: malformed segment %q", path, segment)
+			}
+			n, err := strconv.Atoi(field[open+1 : len(field)-1])
+			if err != nil {
+				return nil, fmt.Errorf("reference %q: bad index in %q", path, segment)
+			}
+			indexes = append([]int{n}, indexes...)
+			field = field[:open]
+		}
+		if field != "" {
+			obj, ok := current.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("reference %q: %q is not an object", path, field)
+			}
+			current, ok = obj[field]
+			if !ok {
+				return nil, fmt.Errorf("reference %q: no field %q", path, field)
+			}
+		}
+		for _, n := range indexes {
+			arr, ok := current.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("reference %q: indexing into a non-array", path)
+			}
+			if n < 0 || n >= len(arr) {
+				return nil, fmt.Errorf("reference %q: index %d out of range (%d items)", path, n, len(arr))
+			}
+			current = arr[n]
+		}
+	}
+	return current, nil
+}
+
+var pipelineCmd = &cobra.Command{
+	Use:   "pipeline",
+	Short: "Run scripted multi-server tool workflows",
+}
+
+var pipelineRunCmd = &cobra.Command{
+	Use:   "run <flow.yaml>",
+	Short: "Run a pipeline of chained tool calls",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		spec, err := loadPipeline(args[0])
+		if err != nil {
+			return err
+		}
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+
+		// One client per distinct server, kept open across steps.
+		clients := map[string]*mcpClient{}
+		defer func() {
+			for _, client := range clients {
+				client.Close()
+			}
+		}()
+
+		results := map[string]interface{}{}
+		var lastResult json.RawMessage
+		for i, step := range spec.Steps {
+			srv := reg.findServer(step.Server)
+			if srv == nil {
+				return fmt.Errorf("step %q: %w", step.Name, serverNotFoundError(step.Server))
+			}
+			client, ok := clients[step.Server]
+			if !ok {
+				client, err = dialStdioServer(srv)
+				if err != nil {
+					return fmt.Errorf("step %q: %w", step.Name, err)
+				}
+				clients[step.Server] = client
+			}
+			stepArgs, err := resolveStepArgs(step.Args, results)
+			if err != nil {
+				return fmt.Errorf("step %q: %w", step.Name, err)
+			}
+
+			started := time.Now()
+			result, err := client.callWithRetry("tools/call", map[string]interface{}{
+				"name":      step.Tool,
+				"arguments": stepArgs,
+			}, 60*time.Second)
+			if err != nil {
+				return fmt.Errorf("step %q (%s/%s): %w", step.Name, step.Server, step.Tool, err)
+			}
+			var decoded interface{}
+			if err := json.Unmarshal(result, &decoded); err != nil {
+				return fmt.Errorf("step %q: server returned invalid JSON: %w", step.Name, err)
+			}
+			results[step.Name] = decoded
+			lastResult = result
+			fmt.Fprintf(os.Stderr, "[%d/%d] %s (%s/%s) ok in %s\n",
+				i+1, len(spec.Steps), step.Name, step.Server, step.Tool,
+				time.Since(started).Round(time.Millisecond))
+		}
+		return showCallResult(lastResult)
+	},
+}
+
+func init() {
+	pipelineRunCmd.Flags().BoolVar(&callPlainFlag, "plain", false, "print JSON instead of opening the viewer")
+	pipelineCmd.AddCommand(pipelineRunCmd)
+	rootCmd.AddCommand(pipelineCmd)
+}
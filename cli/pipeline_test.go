@@ -0,0 +1,79 @@
+package main
+
+import "testing"
+
+func pipelineResults() map[string]interface{} {
+	return map[string]interface{}{
+		"fetch": map[string]interface{}{
+			"content": []interface{}{
+				map[string]interface{}{"type": "text", "text": "hello"},
+			},
+			"count": float64(2),
+		},
+	}
+}
+
+func TestLookupPath(t *testing.T) {
+	results := pipelineResults()
+	tests := []struct {
+		path    string
+		want    interface{}
+		wantErr bool
+	}{
+		{path: "fetch.content[0].text", want: "hello"},
+		{path: "fetch.count", want: float64(2)},
+		{path: "fetch.content[3].text", wantErr: true},
+		{path: "fetch.missing", wantErr: true},
+		{path: "nostep.value", wantErr: true},
+		{path: "fetch.count[0]", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := lookupPath(tt.path, results)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("lookupPath(%q) = %v, want error", tt.path, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("lookupPath(%q): %v", tt.path, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("lookupPath(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestResolveStepArgs(t *testing.T) {
+	results := pipelineResults()
+	args := map[string]interface{}{
+		"typed":    "${fetch.count}",
+		"embedded": "got ${fetch.content[0].text} back",
+		"plain":    "no placeholders",
+		"nested": map[string]interface{}{
+			"inner": []interface{}{"${fetch.content[0].type}"},
+		},
+	}
+	resolved, err := resolveStepArgs(args, results)
+	if err != nil {
+		t.Fatalf("resolveStepArgs: %v", err)
+	}
+	if resolved["typed"] != float64(2) {
+		t.Errorf("typed placeholder lost its type: %v", resolved["typed"])
+	}
+	if resolved["embedded"] != "got hello back" {
+		t.Errorf("embedded = %q", resolved["embedded"])
+	}
+	if resolved["plain"] != "no placeholders" {
+		t.Errorf("plain = %q", resolved["plain"])
+	}
+	nested := resolved["nested"].(map[string]interface{})["inner"].([]interface{})
+	if nested[0] != "text" {
+		t.Errorf("nested = %v", nested[0])
+	}
+
+	if _, err := resolveStepArgs(map[string]interface{}{"bad": "${nostep.x}"}, results); err == nil {
+		t.Error("unresolvable reference should fail the step")
+	}
+}
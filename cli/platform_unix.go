@@ -0,0 +1,51 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// processAlive reports whether a recorded PID still refers to a live
+// process.
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return proc.Signal(syscall.Signal(0)) == nil
+}
+
+// detachProcess configures cmd to run in its own session so it survives
+// the CLI exiting.
+func detachProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
+
+// terminateProcess asks a process to shut down gracefully.
+func terminateProcess(pid int) error {
+	return syscall.Kill(pid, syscall.SIGTERM)
+}
+
+// killProcess forcibly ends a process that ignored terminateProcess.
+func killProcess(pid int) error {
+	return syscall.Kill(pid, syscall.SIGKILL)
+}
+
+// shellCommand returns the platform shell and its "run this string" flag,
+// used for cmd: health checks.
+func shellCommand() (string, string) {
+	return "sh", "-c"
+}
+
+// lockFileHandle takes an exclusive advisory lock on an open file,
+// blocking until the current holder releases it. Closing the file
+// releases the lock.
+func lockFileHandle(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+}
@@ -0,0 +1,64 @@
+//go:build windows
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+// processAlive reports whether a recorded PID still refers to a live
+// process. On Windows, OpenProcess (via FindProcess) fails for dead PIDs.
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	proc.Release()
+	return true
+}
+
+// detachProcess configures cmd to run detached in its own process group so
+// it survives the CLI exiting and console Ctrl-C events.
+func detachProcess(cmd *exec.Cmd) {
+	const detachedProcess = 0x00000008
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP | detachedProcess,
+	}
+}
+
+// terminateProcess ends a process. Windows has no SIGTERM equivalent for
+// detached processes, so this is an immediate kill.
+func terminateProcess(pid int) error {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	defer proc.Release()
+	return proc.Kill()
+}
+
+// killProcess forcibly ends a process; on Windows this matches
+// terminateProcess.
+func killProcess(pid int) error {
+	return terminateProcess(pid)
+}
+
+// shellCommand returns the platform shell and its "run this string" flag,
+// used for cmd: health checks.
+func shellCommand() (string, string) {
+	return "cmd", "/c"
+}
+
+// lockFileHandle takes an exclusive lock on an open file, blocking until
+// the current holder releases it. Closing the file releases the lock.
+func lockFileHandle(f *os.File) error {
+	return windows.LockFileEx(windows.Handle(f.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK,
+		0, 1, 0, &windows.Overlapped{})
+}
@@ -0,0 +1,391 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// The tool playground lets an operator smoke-test a server from inside
+// the dashboard: pick a tool, fill a form generated from its JSON input
+// schema, invoke it, and read the response — no client code required.
+// It owns a live stdio connection for the duration of the overlay.
+
+// playgroundPhase tracks which screen the overlay shows.
+type playgroundPhase int
+
+const (
+	playgroundLoading playgroundPhase = iota
+	playgroundPickTool
+	playgroundForm
+	playgroundCalling
+	playgroundResult
+	playgroundFailed
+)
+
+// playgroundField is one form input derived from a schema property.
+type playgroundField struct {
+	name        string
+	fieldType   string // JSON schema type; "" when unspecified
+	description string
+	required    bool
+	input       textinput.Model
+}
+
+type playgroundModel struct {
+	server MCPServer
+	client *mcpClient
+	phase  playgroundPhase
+	tools  []toolInfo
+	cursor int
+	tool   toolInfo
+	fields []playgroundField
+	focus  int
+	result string // non-JSON results shown verbatim
+	viewer *jsonViewerModel
+	err    error
+	styles dashboardStyles
+	width  int
+}
+
+// playgroundOpenedMsg delivers the dialed client and tool list.
+type playgroundOpenedMsg struct {
+	client *mcpClient
+	tools  []toolInfo
+	err    error
+}
+
+// playgroundCalledMsg delivers one tools/call outcome.
+type playgroundCalledMsg struct {
+	result json.RawMessage
+	err    error
+}
+
+// openPlaygroundCmd dials the server and enumerates its tools off the UI
+// goroutine.
+func openPlaygroundCmd(srv MCPServer) tea.Cmd {
+	return func() tea.Msg {
+		client, err := dialStdioServer(&srv)
+		if err != nil {
+			return playgroundOpenedMsg{err: err}
+		}
+		tools, err := client.listTools()
+		if err != nil {
+			client.Close()
+			return playgroundOpenedMsg{err: fmt.Errorf("tools/list: %w", err)}
+		}
+		return playgroundOpenedMsg{client: client, tools: tools}
+	}
+}
+
+// callToolCmd invokes the tool with the given arguments.
+func callToolCmd(client *mcpClient, tool string, args map[string]interface{}) tea.Cmd {
+	return func() tea.Msg {
+		result, err := client.call("tools/call", map[string]interface{}{
+			"name":      tool,
+			"arguments": args,
+		}, 30*time.Second)
+		return playgroundCalledMsg{result: result, err: err}
+	}
+}
+
+// buildPlaygroundFields generates form fields from a tool's input schema.
+// Schemas that aren't objects (or are absent) yield an empty form, which
+// submits {} — exactly what a no-argument tool expects.
+func buildPlaygroundFields(tool toolInfo) []playgroundField {
+	var schema struct {
+		Properties map[string]struct {
+			Type        string `json:"type"`
+			Description string `json:"description"`
+		} `json:"properties"`
+		Required []string `json:"required"`
+	}
+	if len(tool.InputSchema) > 0 {
+		if err := json.Unmarshal(tool.InputSchema, &schema); err != nil {
+			return nil
+		}
+	}
+	required := map[string]bool{}
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]playgroundField, 0, len(names))
+	for _, name := range names {
+		prop := schema.Properties[name]
+		input := textinput.New()
+		input.Prompt = ""
+		input.Placeholder = prop.Type
+		fields = append(fields, playgroundField{
+			name:        name,
+			fieldType:   prop.Type,
+			description: prop.Description,
+			required:    required[name],
+			input:       input,
+		})
+	}
+	if len(fields) > 0 {
+		fields[0].input.Focus()
+	}
+	return fields
+}
+
+// playgroundArgValue converts one form value to the schema's type. Empty
+// optional fields are omitted entirely rather than sent as empty strings.
+func playgroundArgValue(fieldType, raw string) (interface{}, error) {
+	switch fieldType {
+	case "number":
+		return strconv.ParseFloat(raw, 64)
+	case "integer":
+		return strconv.Atoi(raw)
+	case "boolean":
+		return strconv.ParseBool(raw)
+	case "array", "object":
+		var value interface{}
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			return nil, fmt.Errorf("expected JSON: %w", err)
+		}
+		return value, nil
+	default:
+		return raw, nil
+	}
+}
+
+// collectArgs validates the form and builds the arguments payload.
+func (p *playgroundModel) collectArgs() (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	for i := range p.fields {
+		field := &p.fields[i]
+		raw := strings.TrimSpace(field.input.Value())
+		if raw == "" {
+			if field.required {
+				return nil, fmt.Errorf("%s is required", field.name)
+			}
+			continue
+		}
+		value, err := playgroundArgValue(field.fieldType, raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", field.name, err)
+		}
+		args[field.name] = value
+	}
+	return args, nil
+}
+
+func (p *playgroundModel) close() {
+	if p.client != nil {
+		p.client.Close()
+		p.client = nil
+	}
+}
+
+// updatePlayground handles messages while the overlay is open. The second
+// return value is false when the overlay should close.
+func (p *playgroundModel) updatePlayground(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case playgroundOpenedMsg:
+		if msg.err != nil {
+			p.phase = playgroundFailed
+			p.err = msg.err
+			return nil, true
+		}
+		p.client = msg.client
+		p.tools = msg.tools
+		p.phase = playgroundPickTool
+		return nil, true
+
+	case playgroundCalledMsg:
+		if msg.err != nil {
+			p.phase = playgroundFailed
+			p.err = msg.err
+			return nil, true
+		}
+		if viewer, err := newJSONViewer(msg.result); err == nil {
+			viewer.width = p.width
+			p.viewer = viewer
+			p.result = ""
+		} else {
+			// Not JSON; show it verbatim rather than refusing.
+			p.viewer = nil
+			p.result = string(msg.result)
+		}
+		p.phase = playgroundResult
+		return nil, true
+
+	case tea.KeyMsg:
+		return p.handleKey(msg)
+	}
+	return nil, true
+}
+
+func (p *playgroundModel) handleKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if msg.String() == "esc" {
+		switch p.phase {
+		case playgroundForm, playgroundResult, playgroundFailed:
+			if p.client != nil {
+				p.phase = playgroundPickTool
+				p.err = nil
+				return nil, true
+			}
+		}
+		p.close()
+		return nil, false
+	}
+	if msg.String() == "ctrl+c" {
+		p.close()
+		return nil, false
+	}
+
+	switch p.phase {
+	case playgroundPickTool:
+		switch msg.String() {
+		case "up", "k":
+			if p.cursor > 0 {
+				p.cursor--
+			}
+		case "down", "j":
+			if p.cursor < len(p.tools)-1 {
+				p.cursor++
+			}
+		case "enter":
+			if len(p.tools) == 0 {
+				return nil, true
+			}
+			p.tool = p.tools[p.cursor]
+			p.fields = buildPlaygroundFields(p.tool)
+			p.focus = 0
+			p.err = nil
+			p.phase = playgroundForm
+		}
+		return nil, true
+
+	case playgroundForm:
+		switch msg.String() {
+		case "tab", "down":
+			p.moveFocus(1)
+			return nil, true
+		case "shift+tab", "up":
+			p.moveFocus(-1)
+			return nil, true
+		case "enter":
+			args, err := p.collectArgs()
+			if err != nil {
+				p.err = err
+				return nil, true
+			}
+			p.err = nil
+			p.phase = playgroundCalling
+			return callToolCmd(p.client, p.tool.Name, args), true
+		}
+		if p.focus < len(p.fields) {
+			var cmd tea.Cmd
+			p.fields[p.focus].input, cmd = p.fields[p.focus].input.Update(msg)
+			return cmd, true
+		}
+		return nil, true
+
+	case playgroundResult:
+		// The embedded viewer owns navigation and folding; esc was handled
+		// above and returns to the tool list.
+		if p.viewer != nil {
+			if !p.viewer.viewerKey(msg) && p.client != nil {
+				p.phase = playgroundPickTool
+				p.err = nil
+			}
+		} else if msg.String() == "enter" && p.client != nil {
+			p.phase = playgroundPickTool
+			p.err = nil
+		}
+		return nil, true
+
+	case playgroundFailed:
+		if msg.String() == "enter" && p.client != nil {
+			p.phase = playgroundPickTool
+			p.err = nil
+		}
+		return nil, true
+	}
+	return nil, true
+}
+
+func (p *playgroundModel) moveFocus(delta int) {
+	if len(p.fields) == 0 {
+		return
+	}
+	p.fields[p.focus].input.Blur()
+	p.focus = (p.focus + delta + len(p.fields)) % len(p.fields)
+	p.fields[p.focus].input.Focus()
+}
+
+func (p *playgroundModel) view() string {
+	width := p.width
+	if width <= 0 {
+		width = defaultDashboardWidth
+	}
+	title := p.styles.title.Render(fmt.Sprintf("⚡ Playground — %s", p.server.Name))
+	var body string
+	switch p.phase {
+	case playgroundLoading:
+		body = "Connecting and listing tools..."
+	case playgroundPickTool:
+		if len(p.tools) == 0 {
+			body = "Server exposes no tools."
+		} else {
+			var b strings.Builder
+			b.WriteString("Select a tool:\n\n")
+			for i, tool := range p.tools {
+				line := fmt.Sprintf("%s  %s", padTo(tool.Name, 32), firstLine(tool.Description))
+				if i == p.cursor {
+					line = p.styles.selected.Render(line)
+				}
+				b.WriteString(line + "\n")
+			}
+			body = b.String()
+		}
+		body += "\n" + p.styles.footer.Render("enter select • esc close")
+	case playgroundForm:
+		var b strings.Builder
+		b.WriteString(fmt.Sprintf("%s\n%s\n\n", p.tool.Name, firstLine(p.tool.Description)))
+		if len(p.fields) == 0 {
+			b.WriteString("No parameters.\n")
+		}
+		for i := range p.fields {
+			field := &p.fields[i]
+			label := field.name
+			if field.required {
+				label += "*"
+			}
+			b.WriteString(fmt.Sprintf("%s %s\n", padTo(label, 24), field.input.View()))
+			if field.description != "" {
+				b.WriteString("  " + p.styles.footer.Render(firstLine(field.description)) + "\n")
+			}
+		}
+		if p.err != nil {
+			b.WriteString("\n" + p.styles.header.Render(p.err.Error()) + "\n")
+		}
+		body = b.String() + "\n" + p.styles.footer.Render("tab next field • enter invoke • esc back")
+	case playgroundCalling:
+		body = fmt.Sprintf("Calling %s...", p.tool.Name)
+	case playgroundResult:
+		if p.viewer != nil {
+			body = p.viewer.view() + "\n" + p.styles.footer.Render("esc back to tools")
+		} else {
+			body = p.result + "\n\n" + p.styles.footer.Render("enter another call • esc back")
+		}
+	case playgroundFailed:
+		body = p.styles.header.Render(fmt.Sprintf("Error: %v", p.err)) +
+			"\n\n" + p.styles.footer.Render("enter retry list • esc close")
+	}
+	return title + "\n\n" + body + "\n"
+}
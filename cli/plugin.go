@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// devgen extends git-style: an executable named devgen-<name> anywhere on
+// PATH becomes the subcommand <name>, tried only when no built-in command
+// matches, so plugins can never shadow the CLI itself. Plugins receive
+// the resolved registry location and config path through the environment
+// instead of re-discovering them.
+
+// pluginPrefix is the executable name prefix plugins must carry.
+const pluginPrefix = "devgen-"
+
+// pluginCandidate returns the would-be plugin name for an invocation, or
+// "" when the first argument is a flag or a built-in command.
+func pluginCandidate(args []string) string {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return ""
+	}
+	name := args[0]
+	if name == "help" || name == "completion" {
+		return ""
+	}
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == name || cmd.HasAlias(name) {
+			return ""
+		}
+	}
+	return name
+}
+
+// findPlugin locates devgen-<name> on PATH.
+func findPlugin(name string) (string, bool) {
+	exe, err := exec.LookPath(pluginPrefix + name)
+	if err != nil {
+		return "", false
+	}
+	return exe, true
+}
+
+// pluginEnviron is the inherited environment plus the devgen context:
+// DEVGEN_REGISTRY points at the resolved registry file when discovery
+// succeeds, DEVGEN_CONFIG at the active config file, and DEVGEN_VERSION
+// carries the host CLI version.
+func pluginEnviron() []string {
+	env := os.Environ()
+	if path, err := registryPath(); err == nil {
+		env = append(env, "DEVGEN_REGISTRY="+path)
+	}
+	if path, err := configFilePath(); err == nil {
+		env = append(env, "DEVGEN_CONFIG="+path)
+	}
+	return append(env, "DEVGEN_VERSION="+devgenVersion)
+}
+
+// runPlugin executes the plugin with the session's streams and returns
+// its exit code.
+func runPlugin(exe string, args []string) int {
+	cmd := exec.Command(exe, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = pluginEnviron()
+	debugf("plugin: exec %s %v", exe, args)
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return exitErr.ExitCode()
+		}
+		fmt.Fprintf(os.Stderr, "devgen: running %s: %v\n", exe, err)
+		return 1
+	}
+	return 0
+}
+
+// scanPlugins walks PATH once and maps plugin name to executable path,
+// keeping the first hit per name — the same one execution would pick.
+func scanPlugins() map[string]string {
+	found := map[string]string{}
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if !strings.HasPrefix(name, pluginPrefix) || entry.IsDir() {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil || info.Mode()&0o111 == 0 {
+				continue
+			}
+			short := strings.TrimPrefix(name, pluginPrefix)
+			if _, seen := found[short]; !seen {
+				found[short] = filepath.Join(dir, name)
+			}
+		}
+	}
+	return found
+}
+
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Inspect git-style devgen-* plugins",
+}
+
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List devgen-* executables found on PATH",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		plugins := scanPlugins()
+		if len(plugins) == 0 {
+			fmt.Println("No plugins found. Put an executable named devgen-<name> on PATH to add one.")
+			return nil
+		}
+		names := make([]string, 0, len(plugins))
+		for name := range plugins {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Printf("%s %s\n", padTo("COMMAND", 24), "SOURCE")
+		for _, name := range names {
+			shadowed := ""
+			if pluginCandidate([]string{name}) == "" {
+				shadowed = "  (shadowed by built-in command)"
+			}
+			fmt.Printf("%s %s%s\n", padTo(name, 24), plugins[name], shadowed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginListCmd)
+	rootCmd.AddCommand(pluginCmd)
+}
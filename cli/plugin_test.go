@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestPluginCandidate(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{args: nil, want: ""},
+		{args: []string{"--version"}, want: ""},
+		{args: []string{"server", "list"}, want: ""}, // built-in
+		{args: []string{"help"}, want: ""},           // cobra special
+		{args: []string{"deploy", "--env"}, want: "deploy"},
+		{args: []string{"lint"}, want: "lint"},
+	}
+	for _, tt := range tests {
+		if got := pluginCandidate(tt.args); got != tt.want {
+			t.Errorf("pluginCandidate(%v) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestScanPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("exec-bit scan is unix-specific")
+	}
+	dir := t.TempDir()
+	write := func(name string, mode os.FileMode) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\nexit 0\n"), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("devgen-deploy", 0o755)
+	write("devgen-notes", 0o644) // not executable: must be skipped
+	write("unrelated", 0o755)
+	t.Setenv("PATH", dir)
+
+	plugins := scanPlugins()
+	if got, want := plugins["deploy"], filepath.Join(dir, "devgen-deploy"); got != want {
+		t.Errorf("deploy = %q, want %q", got, want)
+	}
+	if _, ok := plugins["notes"]; ok {
+		t.Error("non-executable devgen-notes should not be listed")
+	}
+	if len(plugins) != 1 {
+		t.Errorf("got %d plugins, want 1: %v", len(plugins), plugins)
+	}
+
+	if exe, ok := findPlugin("deploy"); !ok || exe != filepath.Join(dir, "devgen-deploy") {
+		t.Errorf("findPlugin(deploy) = %q, %v", exe, ok)
+	}
+	if _, ok := findPlugin("notes"); ok {
+		t.Error("findPlugin should not resolve non-executables")
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// parsePortRange parses "3000-9000" or a single "8080".
+func parsePortRange(spec string) (int, int, error) {
+	if from, to, ok := strings.Cut(spec, "-"); ok {
+		lo, err := strconv.Atoi(from)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid port range %q", spec)
+		}
+		hi, err := strconv.Atoi(to)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid port range %q", spec)
+		}
+		if lo < 1 || hi > 65535 || lo > hi {
+			return 0, 0, fmt.Errorf("port range %q out of bounds", spec)
+		}
+		return lo, hi, nil
+	}
+	port, err := strconv.Atoi(spec)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, 0, fmt.Errorf("invalid port %q", spec)
+	}
+	return port, port, nil
+}
+
+// scanPorts probes localhost ports for MCP-over-HTTP endpoints by sending
+// an initialize request and checking for a valid response.
+func scanPorts(lo, hi int) []discoveredServer {
+	const concurrency = 128
+	sem := make(chan struct{}, concurrency)
+	var (
+		mu    sync.Mutex
+		found []discoveredServer
+		wg    sync.WaitGroup
+	)
+	for port := lo; port <= hi; port++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(port int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if candidate, ok := probeMCPPort(port); ok {
+				mu.Lock()
+				found = append(found, candidate)
+				mu.Unlock()
+			}
+		}(port)
+	}
+	wg.Wait()
+	return found
+}
+
+// probeMCPPort checks whether a localhost port speaks MCP over HTTP.
+func probeMCPPort(port int) (discoveredServer, bool) {
+	addr := fmt.Sprintf("127.0.0.1:%d", port)
+	conn, err := net.DialTimeout("tcp", addr, 300*time.Millisecond)
+	if err != nil {
+		return discoveredServer{}, false
+	}
+	conn.Close()
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "initialize",
+		"params": map[string]interface{}{
+			"protocolVersion": mcpProtocolVersion,
+			"capabilities":    map[string]interface{}{},
+			"clientInfo":      map[string]string{"name": "devgen", "version": devgenVersion},
+		},
+	})
+	resp, err := outboundHTTPClient(2*time.Second).Post("http://"+addr, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return discoveredServer{}, false
+	}
+	defer resp.Body.Close()
+
+	var parsed struct {
+		Result *initializeResult `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil || parsed.Result == nil {
+		return discoveredServer{}, false
+	}
+	if parsed.Result.ProtocolVersion == "" {
+		return discoveredServer{}, false
+	}
+	name := parsed.Result.ServerInfo.Name
+	if name == "" {
+		name = fmt.Sprintf("mcp-port-%d", port)
+	}
+	return discoveredServer{
+		Name:     strings.ToLower(name),
+		Endpoint: "http://" + addr,
+	}, true
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// presetsFileName lives in the machina root, next to the registry, so
+// saved invocations are committed and shared with the whole team.
+const presetsFileName = "devgen_presets.json"
+
+// toolPreset is one saved tool invocation.
+type toolPreset struct {
+	Server    string                 `json:"server"`
+	Tool      string                 `json:"tool"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	SavedBy   string                 `json:"saved_by,omitempty"`
+	SavedAt   string                 `json:"saved_at,omitempty"`
+}
+
+type presetsFile struct {
+	Presets map[string]toolPreset `json:"presets"`
+}
+
+func presetsPath() (string, error) {
+	root, err := findMachinaRoot()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, presetsFileName), nil
+}
+
+func loadPresets() (*presetsFile, error) {
+	path, err := presetsPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &presetsFile{Presets: map[string]toolPreset{}}, nil
+		}
+		return nil, err
+	}
+	var file presetsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if file.Presets == nil {
+		file.Presets = map[string]toolPreset{}
+	}
+	return &file, nil
+}
+
+func savePresets(file *presetsFile) error {
+	path, err := presetsPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// savePreset records a successful invocation under the given name,
+// overwriting any previous preset with that name.
+func savePreset(name, server, tool string, args map[string]interface{}) error {
+	file, err := loadPresets()
+	if err != nil {
+		return err
+	}
+	file.Presets[name] = toolPreset{
+		Server:    server,
+		Tool:      tool,
+		Arguments: args,
+		SavedBy:   currentActor(),
+		SavedAt:   time.Now().UTC().Format(time.RFC3339),
+	}
+	return savePresets(file)
+}
+
+var runCmd = &cobra.Command{
+	Use:   "run [preset]",
+	Short: "Replay a saved tool call preset",
+	Long: `Replays a tool invocation saved with 'devgen call --save <name>'.
+Presets live in devgen_presets.json in the machina root, so they can be
+committed and shared as team-wide smoke tests. Without an argument the
+saved presets are listed.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := loadPresets()
+		if err != nil {
+			return err
+		}
+		if len(args) == 0 {
+			if len(file.Presets) == 0 {
+				fmt.Println("No presets saved. Save one with 'devgen call <server> <tool> --save <name>'.")
+				return nil
+			}
+			names := make([]string, 0, len(file.Presets))
+			for name := range file.Presets {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			fmt.Printf("%s %s %s %s\n", padTo("PRESET", 20), padTo("SERVER", 20), padTo("TOOL", 24), "SAVED BY")
+			for _, name := range names {
+				preset := file.Presets[name]
+				fmt.Printf("%s %s %s %s\n", padTo(name, 20), padTo(preset.Server, 20), padTo(preset.Tool, 24), preset.SavedBy)
+			}
+			return nil
+		}
+
+		preset, ok := file.Presets[args[0]]
+		if !ok {
+			return fmt.Errorf("no preset named %q; run 'devgen run' to list them", args[0])
+		}
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		srv := reg.findServer(preset.Server)
+		if srv == nil {
+			return fmt.Errorf("preset %q targets unknown server %q", args[0], preset.Server)
+		}
+		client, err := dialStdioServer(srv)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		result, err := client.callWithRetry("tools/call", map[string]interface{}{
+			"name":      preset.Tool,
+			"arguments": preset.Arguments,
+		}, 30*time.Second)
+		if err != nil {
+			return fmt.Errorf("tools/call %s: %w", preset.Tool, err)
+		}
+		return showCallResult(result)
+	},
+}
+
+func init() {
+	runCmd.Flags().BoolVar(&callPlainFlag, "plain", false, "print JSON instead of opening the viewer")
+	rootCmd.AddCommand(runCmd)
+}
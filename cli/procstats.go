@@ -0,0 +1,9 @@
+package main
+
+// procSample is a point-in-time resource measurement of one process.
+// Sampling is implemented per platform; see procstats_linux.go.
+type procSample struct {
+	CPUPercent   float64
+	RSSMegabytes float64
+	OpenFDs      int
+}
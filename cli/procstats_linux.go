@@ -0,0 +1,93 @@
+//go:build linux
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sampleProcess measures CPU, RSS, and open file descriptors for a PID via
+// /proc. CPU is computed over a short sampling window.
+func sampleProcess(pid int) (procSample, error) {
+	first, err := readCPUTicks(pid)
+	if err != nil {
+		return procSample{}, err
+	}
+	const window = 200 * time.Millisecond
+	time.Sleep(window)
+	second, err := readCPUTicks(pid)
+	if err != nil {
+		return procSample{}, err
+	}
+
+	ticksPerSecond := 100.0 // USER_HZ on Linux
+	cpuSeconds := float64(second-first) / ticksPerSecond
+	sample := procSample{
+		CPUPercent: cpuSeconds / window.Seconds() * 100,
+	}
+
+	sample.RSSMegabytes, err = readRSSMegabytes(pid)
+	if err != nil {
+		return procSample{}, err
+	}
+	entries, err := os.ReadDir(fmt.Sprintf("/proc/%d/fd", pid))
+	if err == nil {
+		sample.OpenFDs = len(entries)
+	}
+	return sample, nil
+}
+
+// readCPUTicks returns utime+stime from /proc/<pid>/stat.
+func readCPUTicks(pid int) (uint64, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, fmt.Errorf("process %d not measurable: %w", pid, err)
+	}
+	// The comm field may contain spaces; fields start after the closing
+	// paren. utime and stime are fields 14 and 15 (1-based).
+	raw := string(data)
+	idx := strings.LastIndexByte(raw, ')')
+	if idx < 0 {
+		return 0, fmt.Errorf("unexpected /proc stat format for pid %d", pid)
+	}
+	fields := strings.Fields(raw[idx+1:])
+	if len(fields) < 13 {
+		return 0, fmt.Errorf("unexpected /proc stat format for pid %d", pid)
+	}
+	utime, err := strconv.ParseUint(fields[11], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	stime, err := strconv.ParseUint(fields[12], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return utime + stime, nil
+}
+
+// readRSSMegabytes parses VmRSS from /proc/<pid>/status.
+func readRSSMegabytes(pid int) (float64, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/status", pid))
+	if err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(line, "VmRSS:") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			break
+		}
+		kb, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return 0, err
+		}
+		return kb / 1024, nil
+	}
+	return 0, nil
+}
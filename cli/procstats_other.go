@@ -0,0 +1,11 @@
+//go:build !linux
+
+package main
+
+import "fmt"
+
+// sampleProcess is unavailable without /proc: callers surface the error
+// instead of fabricating resource numbers.
+func sampleProcess(pid int) (procSample, error) {
+	return procSample{}, fmt.Errorf("resource sampling for pid %d requires /proc (linux only)", pid)
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// jsonRPCMessage is the subset of a JSON-RPC 2.0 frame the proxy needs to
+// pair requests with responses.
+type jsonRPCMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Error   *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// pendingCall tracks an in-flight request awaiting its response.
+type pendingCall struct {
+	method  string
+	started time.Time
+	reqSize int
+	reqBody json.RawMessage
+}
+
+var proxyCmd = &cobra.Command{
+	Use:   "proxy <server>",
+	Short: "Proxy stdio MCP traffic to a server, capturing request/response pairs",
+	Long: `Runs the named stdio MCP server as a child process and relays JSON-RPC
+traffic between it and stdin/stdout, recording sanitized request/response
+pairs (with sizes and durations) to the traffic log. Point an MCP client at
+'devgen proxy <server>' instead of the server itself to capture its traffic
+for later inspection with 'devgen traffic'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		srv := reg.findServer(args[0])
+		if srv == nil {
+			return serverNotFoundError(args[0])
+		}
+		if inMaintenance(srv) {
+			return fmt.Errorf("%s is in maintenance; run 'devgen maintenance %s off' to route traffic again",
+				srv.Name, srv.Name)
+		}
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		collisions, err := applyCollisionPolicy(reg, srv, cfg.Proxy)
+		if err != nil {
+			return err
+		}
+		return runProxy(srv, collisions, cfg.Proxy)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(proxyCmd)
+}
+
+// runProxy launches the server process and relays newline-delimited JSON-RPC
+// frames in both directions, logging paired traffic and applying the tool
+// collision policy to tools/list and tools/call frames.
+func runProxy(srv *MCPServer, collisions map[string][]string, proxyCfg proxyConfig) error {
+	launch, err := resolveLaunchCommand(srv)
+	if err != nil {
+		return err
+	}
+
+	logger, err := newTrafficLogger()
+	if err != nil {
+		return err
+	}
+	defer logger.Close()
+
+	child := exec.Command(launch.Command, launch.Args...)
+	child.Dir = launch.Dir
+	child.Env = launchEnviron(launch)
+	child.Stderr = os.Stderr
+	childIn, err := child.StdinPipe()
+	if err != nil {
+		return err
+	}
+	childOut, err := child.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := child.Start(); err != nil {
+		return fmt.Errorf("starting %s: %w", srv.Name, err)
+	}
+	debugf("proxy: started %s (%s %v)", srv.Name, launch.Command, launch.Args)
+
+	var (
+		mu      sync.Mutex
+		pending = map[string]pendingCall{}
+	)
+
+	// Client -> server: record requests so responses can be paired by id.
+	go func() {
+		scanner := bufio.NewScanner(os.Stdin)
+		scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			var msg jsonRPCMessage
+			if json.Unmarshal(line, &msg) == nil && msg.Method == "tools/call" && len(collisions) > 0 {
+				line = rewriteToolCallRequest(line, srv.Name)
+			}
+			if json.Unmarshal(line, &msg) == nil && msg.Method != "" && msg.ID != nil {
+				mu.Lock()
+				pending[string(msg.ID)] = pendingCall{
+					method:  msg.Method,
+					started: time.Now(),
+					reqSize: len(line),
+					reqBody: sanitizePayload(append(json.RawMessage(nil), line...)),
+				}
+				mu.Unlock()
+			}
+			childIn.Write(append(append([]byte(nil), line...), '\n'))
+		}
+		childIn.Close()
+	}()
+
+	// Server -> client: pair responses with pending requests and log.
+	scanner := bufio.NewScanner(childOut)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		var msg jsonRPCMessage
+		if json.Unmarshal(line, &msg) == nil && msg.ID != nil && msg.Method == "" {
+			mu.Lock()
+			call, ok := pending[string(msg.ID)]
+			if ok {
+				delete(pending, string(msg.ID))
+			}
+			mu.Unlock()
+			if ok && call.method == "tools/list" {
+				line = rewriteToolsListResponse(line, srv.Name, collisions, proxyCfg)
+			}
+			if ok {
+				rec := trafficRecord{
+					Timestamp:     call.started.UTC().Format(time.RFC3339),
+					Server:        srv.Name,
+					Method:        call.method,
+					ID:            msg.ID,
+					DurationMs:    float64(time.Since(call.started).Microseconds()) / 1000,
+					RequestBytes:  call.reqSize,
+					ResponseBytes: len(line),
+					Request:       call.reqBody,
+					Response:      sanitizePayload(append(json.RawMessage(nil), line...)),
+				}
+				if msg.Error != nil {
+					rec.Error = fmt.Sprintf("%d: %s", msg.Error.Code, msg.Error.Message)
+				}
+				logger.record(rec)
+			}
+		}
+		os.Stdout.Write(append(append([]byte(nil), line...), '\n'))
+	}
+	if err := scanner.Err(); err != nil && err != io.EOF {
+		return err
+	}
+	return child.Wait()
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// applyPythonEnvironment rewrites a launch command so the server runs under
+// the Python environment its registry entry declares: an explicit venv, or
+// a uv/poetry project. Missing environments produce actionable errors
+// instead of an interpreter falling back to system site-packages.
+func applyPythonEnvironment(srv *MCPServer, launch *launchCommand) error {
+	spec := srv.Launch
+	if spec == nil {
+		return nil
+	}
+
+	if spec.Venv != "" {
+		interpreter := filepath.Join(spec.Venv, "bin", "python")
+		if _, err := os.Stat(interpreter); err != nil {
+			return fmt.Errorf(
+				"server %s: virtualenv interpreter not found at %s (create it with 'python3 -m venv %s' and install dependencies)",
+				srv.Name, interpreter, spec.Venv)
+		}
+		if isPythonInterpreter(launch.Command) {
+			launch.Command = interpreter
+		} else {
+			launch.Args = append([]string{launch.Command}, launch.Args...)
+			launch.Command = interpreter
+		}
+		return nil
+	}
+
+	if spec.Project != "" {
+		tool, err := detectProjectTool(spec.Project)
+		if err != nil {
+			return fmt.Errorf("server %s: %w", srv.Name, err)
+		}
+		if _, err := exec.LookPath(tool); err != nil {
+			return fmt.Errorf("server %s: project at %s needs %q, which is not installed", srv.Name, spec.Project, tool)
+		}
+		launch.Args = append([]string{"run", launch.Command}, launch.Args...)
+		launch.Command = tool
+		if launch.Dir == "" {
+			launch.Dir = spec.Project
+		}
+		return nil
+	}
+	return nil
+}
+
+// detectProjectTool decides whether a project directory is managed by uv or
+// poetry, based on its lock files.
+func detectProjectTool(project string) (string, error) {
+	if _, err := os.Stat(filepath.Join(project, "uv.lock")); err == nil {
+		return "uv", nil
+	}
+	if _, err := os.Stat(filepath.Join(project, "poetry.lock")); err == nil {
+		return "poetry", nil
+	}
+	if _, err := os.Stat(filepath.Join(project, "pyproject.toml")); err == nil {
+		// A pyproject without a lock file: prefer uv, the faster default.
+		return "uv", nil
+	}
+	return "", fmt.Errorf("no pyproject.toml found in project directory %s", project)
+}
+
+func isPythonInterpreter(command string) bool {
+	base := filepath.Base(command)
+	return base == "python" || base == "python3" || strings.HasPrefix(base, "python3.")
+}
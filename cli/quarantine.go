@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Crash loop policy: more than crashLoopThreshold restarts inside
+// crashLoopWindow quarantines the server instead of restarting again.
+const (
+	crashLoopThreshold = 3
+	crashLoopWindow    = 5 * time.Minute
+)
+
+// statusQuarantined marks a server pulled out of supervision after a crash
+// loop; it stays quarantined until an operator starts it again.
+const statusQuarantined = "quarantined"
+
+// recordRestart appends a restart timestamp and prunes entries older than
+// the crash loop window, returning how many restarts remain inside it.
+func recordRestart(proc *managedProcess) int {
+	now := time.Now().UTC()
+	cutoff := now.Add(-crashLoopWindow)
+	var recent []string
+	for _, raw := range proc.Restarts {
+		ts, err := time.Parse(time.RFC3339, raw)
+		if err == nil && ts.After(cutoff) {
+			recent = append(recent, raw)
+		}
+	}
+	recent = append(recent, now.Format(time.RFC3339))
+	proc.Restarts = recent
+	return len(recent)
+}
+
+// quarantineServer marks the server quarantined in the registry and raises
+// a notification on stderr.
+func quarantineServer(srv *MCPServer) error {
+	path, err := registryPath()
+	if err != nil {
+		return err
+	}
+	if err := mutateServer(path, srv.Name, func(entry *MCPServer) error {
+		entry.Status = statusQuarantined
+		return nil
+	}); err != nil {
+		return err
+	}
+	srv.Status = statusQuarantined
+	notice := fmt.Sprintf(
+		"WARNING: %s restarted more than %d times in %s and has been quarantined; "+
+			"inspect logs and run 'devgen start %s' to release it",
+		srv.Name, crashLoopThreshold, crashLoopWindow, srv.Name)
+	if owner := ownerLine(srv); owner != "" {
+		notice += " (" + owner + ")"
+	}
+	fmt.Fprintln(os.Stderr, notice)
+	emitEvent("text", event{Event: "server_quarantined", Server: srv.Name, Fields: map[string]string{
+		"restarts": fmt.Sprintf("%d", crashLoopThreshold),
+		"window":   crashLoopWindow.String(),
+	}})
+	return nil
+}
+
+// releaseQuarantine clears the quarantined status when an operator starts
+// the server manually.
+func releaseQuarantine(srv *MCPServer) error {
+	path, err := registryPath()
+	if err != nil {
+		return err
+	}
+	if err := mutateServer(path, srv.Name, func(entry *MCPServer) error {
+		if entry.Status == statusQuarantined {
+			entry.Status = "active"
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	srv.Status = "active"
+	fmt.Printf("Released %s from quarantine\n", srv.Name)
+	return nil
+}
+
+// tailServerLog returns the last n lines of a managed server's log file,
+// used to surface stderr from crashing servers.
+func tailServerLog(name string, n int) []string {
+	root, err := findMachinaRoot()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(root, "logs", name+".log"))
+	if err != nil {
+		return nil
+	}
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	if len(lines) == 1 && lines[0] == "" {
+		return nil
+	}
+	return lines
+}
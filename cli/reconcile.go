@@ -0,0 +1,185 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+// Reconciliation compares the local file registry with the HTTP registry
+// endpoint and reports drift: servers that exist on only one side, and
+// shared servers whose status or version differ. The file is treated as
+// the source of truth, matching 'registry register': --fix pushes
+// local-only and drifted entries to the endpoint and deregisters entries
+// the file no longer has.
+
+// driftEntry is one detected difference between the two registries.
+type driftEntry struct {
+	Server string `json:"server"`
+	Kind   string `json:"kind"` // local_only, remote_only, status, version
+	Local  string `json:"local,omitempty"`
+	Remote string `json:"remote,omitempty"`
+}
+
+// registryDrift lists drift between the file and HTTP registries,
+// sorted by server name for stable output.
+func registryDrift(local, remote *MCPRegistry) []driftEntry {
+	var drift []driftEntry
+	remoteByName := map[string]*MCPServer{}
+	for i := range remote.Servers {
+		remoteByName[remote.Servers[i].Name] = &remote.Servers[i]
+	}
+	seen := map[string]bool{}
+	for i := range local.Servers {
+		srv := &local.Servers[i]
+		seen[srv.Name] = true
+		other, ok := remoteByName[srv.Name]
+		if !ok {
+			drift = append(drift, driftEntry{Server: srv.Name, Kind: "local_only"})
+			continue
+		}
+		if srv.Status != other.Status {
+			drift = append(drift, driftEntry{Server: srv.Name, Kind: "status", Local: srv.Status, Remote: other.Status})
+		}
+		if srv.Version != other.Version {
+			drift = append(drift, driftEntry{Server: srv.Name, Kind: "version", Local: srv.Version, Remote: other.Version})
+		}
+	}
+	for _, srv := range remote.Servers {
+		if !seen[srv.Name] {
+			drift = append(drift, driftEntry{Server: srv.Name, Kind: "remote_only"})
+		}
+	}
+	sort.Slice(drift, func(i, j int) bool {
+		if drift[i].Server != drift[j].Server {
+			return drift[i].Server < drift[j].Server
+		}
+		return drift[i].Kind < drift[j].Kind
+	})
+	return drift
+}
+
+// fetchRemoteRegistry reads the full registry document from the HTTP
+// endpoint.
+func fetchRemoteRegistry(baseURL string) (*MCPRegistry, error) {
+	resp, err := outboundRetryPolicy().doHTTP(registryHTTPClient(), func() (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, baseURL+"/registry", nil)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s/registry: %w", baseURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		detail, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("fetching %s/registry: %s: %s", baseURL, resp.Status, detail)
+	}
+	var reg MCPRegistry
+	if err := json.NewDecoder(resp.Body).Decode(&reg); err != nil {
+		return nil, fmt.Errorf("parsing registry from %s: %w", baseURL, err)
+	}
+	return &reg, nil
+}
+
+var (
+	reconcileURLFlag  string
+	reconcileFixFlag  bool
+	reconcileJSONFlag bool
+)
+
+var reconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Detect drift between the file and HTTP registries",
+	Long: `Compares mcp_status.json with the HTTP registry endpoint and reports
+servers missing on either side or carrying a different status or version.
+The file registry is the source of truth: --fix re-registers local-only
+and drifted servers with the endpoint and deregisters servers the file no
+longer contains. Without --fix the command exits non-zero when drift is
+found, so a cron job can alert on it.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !cmd.Flags().Changed("url") {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			if cfg.RegistryURL != "" {
+				reconcileURLFlag = cfg.RegistryURL
+			}
+		}
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		local, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		remote, err := fetchRemoteRegistry(reconcileURLFlag)
+		if err != nil {
+			return err
+		}
+		drift := registryDrift(local, remote)
+
+		if reconcileJSONFlag {
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(drift); err != nil {
+				return err
+			}
+		} else if len(drift) == 0 {
+			fmt.Println("registries are in sync")
+		} else {
+			fmt.Printf("%-24s %-12s %-18s %s\n", "SERVER", "DRIFT", "LOCAL", "REMOTE")
+			for _, entry := range drift {
+				local, remote := entry.Local, entry.Remote
+				if entry.Kind == "local_only" {
+					local, remote = "present", "-"
+				}
+				if entry.Kind == "remote_only" {
+					local, remote = "-", "present"
+				}
+				fmt.Printf("%-24s %-12s %-18s %s\n", entry.Server, entry.Kind, local, remote)
+			}
+		}
+		if len(drift) == 0 {
+			return nil
+		}
+		if !reconcileFixFlag {
+			return fmt.Errorf("%d drift entr(ies) between file and %s", len(drift), reconcileURLFlag)
+		}
+
+		fixed := map[string]bool{}
+		for _, entry := range drift {
+			if fixed[entry.Server] {
+				continue
+			}
+			fixed[entry.Server] = true
+			if entry.Kind == "remote_only" {
+				if err := deregisterFromEndpoint(reconcileURLFlag, entry.Server); err != nil {
+					return err
+				}
+				fmt.Printf("Deregistered %s (not in file registry)\n", entry.Server)
+				continue
+			}
+			srv := local.findServer(entry.Server)
+			if srv == nil {
+				continue
+			}
+			if err := registerWithEndpoint(reconcileURLFlag, srv); err != nil {
+				return err
+			}
+			fmt.Printf("Re-registered %s from file registry\n", entry.Server)
+		}
+		return nil
+	},
+}
+
+func init() {
+	reconcileCmd.Flags().StringVar(&reconcileURLFlag, "url", defaultRegistryURL, "HTTP registry endpoint base URL")
+	reconcileCmd.Flags().BoolVar(&reconcileFixFlag, "fix", false, "push the file registry's view to the endpoint")
+	reconcileCmd.Flags().BoolVar(&reconcileJSONFlag, "json", false, "emit drift entries as JSON")
+	rootCmd.AddCommand(reconcileCmd)
+}
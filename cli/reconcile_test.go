@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestDiffRegistries(t *testing.T) {
+	local := &MCPRegistry{Servers: []MCPServer{
+		{Name: "context7", Status: "active", Version: "1.2.0"},
+		{Name: "bayes", Status: "active", Version: "0.9.0"},
+		{Name: "surrealdb", Status: "locked", Version: "2.0.0"},
+	}}
+	remote := &MCPRegistry{Servers: []MCPServer{
+		{Name: "context7", Status: "stale", Version: "1.2.0"},
+		{Name: "surrealdb", Status: "locked", Version: "1.9.0"},
+		{Name: "ptolemies", Status: "active"},
+	}}
+
+	drift := registryDrift(local, remote)
+	want := []driftEntry{
+		{Server: "bayes", Kind: "local_only"},
+		{Server: "context7", Kind: "status", Local: "active", Remote: "stale"},
+		{Server: "ptolemies", Kind: "remote_only"},
+		{Server: "surrealdb", Kind: "version", Local: "2.0.0", Remote: "1.9.0"},
+	}
+	if len(drift) != len(want) {
+		t.Fatalf("drift = %+v, want %d entries", drift, len(want))
+	}
+	for i, entry := range want {
+		if drift[i] != entry {
+			t.Errorf("drift[%d] = %+v, want %+v", i, drift[i], entry)
+		}
+	}
+}
+
+func TestDiffRegistriesInSync(t *testing.T) {
+	reg := &MCPRegistry{Servers: []MCPServer{{Name: "context7", Status: "active", Version: "1.0.0"}}}
+	if drift := registryDrift(reg, reg); len(drift) != 0 {
+		t.Errorf("drift against itself = %+v", drift)
+	}
+}
@@ -0,0 +1,423 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// registryFileName is the registry file written by registry/main.py.
+const registryFileName = "mcp_status.json"
+
+// machinaMarkerName is an empty marker file that pins the repo root for
+// checkouts that don't have a registry file yet.
+const machinaMarkerName = ".machina"
+
+// MCPServer mirrors a server record in mcp_status.json. The JSON keys match
+// the snake_case fields produced by the Python registry.
+type MCPServer struct {
+	Name        string   `json:"name"`
+	Endpoint    string   `json:"endpoint"`
+	Framework   string   `json:"framework,omitempty"`
+	Status      string   `json:"status,omitempty"`
+	Version     string   `json:"version,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Category    string   `json:"category,omitempty"`
+	Tools       []string `json:"tools,omitempty"`
+	Resources   []string `json:"resources,omitempty"`
+	Prompts     []string `json:"prompts,omitempty"`
+	// Roots are the filesystem directories a filesystem-like server is
+	// allowed to serve; see roots.go for the sensitivity warnings.
+	Roots []string `json:"roots,omitempty"`
+	// Tags are free-form key=value labels for selector filtering, distinct
+	// from the controlled category vocabulary.
+	Tags map[string]string `json:"tags,omitempty"`
+	// ExportTo restricts which clients this server is exported to
+	// (claude, cursor, windsurf, or cli-only); see exportclient.go.
+	ExportTo []string `json:"export_to,omitempty"`
+	// Owner and Contact say whom to page when the server misbehaves; see
+	// owner.go and devgen who.
+	Owner   string `json:"owner,omitempty"`
+	Contact string `json:"contact,omitempty"`
+	// SLATarget is the expected availability percentage checked by
+	// devgen sla report.
+	SLATarget       float64  `json:"sla_target,omitempty"`
+	EnvironmentVars []string `json:"environment_vars,omitempty"`
+	HealthCheck     string   `json:"health_check,omitempty"`
+	// Health tunes flap damping for this server's probes; see damping.go.
+	Health  *HealthThresholds `json:"health_thresholds,omitempty"`
+	Launch  *LaunchSpec       `json:"launch,omitempty"`
+	Sandbox *SandboxSpec      `json:"sandbox,omitempty"`
+	Limits  *ResourceLimits   `json:"limits,omitempty"`
+	Restart *RestartPolicy    `json:"restart,omitempty"`
+	Enabled bool              `json:"enabled"`
+	// ShipLogs forwards this server's captured output to the configured
+	// logs endpoint; see logship.go.
+	ShipLogs bool `json:"ship_logs,omitempty"`
+	// MaintenanceUntil bounds a maintenance window; see maintenance.go.
+	MaintenanceUntil string `json:"maintenance_until,omitempty"`
+	LastHealthCheck  string `json:"last_health_check,omitempty"`
+	LastHeartbeat    string `json:"last_heartbeat,omitempty"`
+	// Notes are operator annotations appended by devgen annotate.
+	Notes []serverNote `json:"notes,omitempty"`
+	// Provenance holds an optional signature over the launch command; see
+	// signing.go.
+	Provenance *ServerProvenance `json:"provenance,omitempty"`
+}
+
+// ServerProvenance records who signed a server's launch command and the
+// detached signature, so tampering with commands that execute arbitrary
+// code is detectable.
+type ServerProvenance struct {
+	SignedBy  string `json:"signed_by"`
+	SignedAt  string `json:"signed_at"`
+	PublicKey string `json:"public_key"`
+	Signature string `json:"signature"`
+}
+
+// LaunchSpec describes how to start a stdio server. When present it takes
+// precedence over deriving a command from the endpoint path.
+type LaunchSpec struct {
+	Command     string            `json:"command"`
+	Args        []string          `json:"args,omitempty"`
+	Cwd         string            `json:"cwd,omitempty"`
+	Interpreter string            `json:"interpreter,omitempty"`
+	Env         map[string]string `json:"env,omitempty"`
+	// Venv points at a Python virtualenv whose interpreter should run the
+	// server. Project points at a uv or poetry project directory; the
+	// server is then launched through that tool's run command.
+	Venv    string `json:"venv,omitempty"`
+	Project string `json:"project,omitempty"`
+	// NpmPackage names an npm package (e.g. @scope/server) launched via
+	// npx, pinned to NpmVersion when set.
+	NpmPackage string `json:"npm_package,omitempty"`
+	NpmVersion string `json:"npm_version,omitempty"`
+	// Image names a container image running this server, used by
+	// 'devgen export compose'; the fields above still describe the
+	// non-container launch.
+	Image string `json:"image,omitempty"`
+}
+
+// HealthThresholds damps health state flapping: a server is only marked
+// down after FailuresToDown consecutive probe failures and back up after
+// SuccessesToUp consecutive successes, with at most one transition per
+// Cooldown window. Zero values fall back to the defaults in damping.go.
+type HealthThresholds struct {
+	FailuresToDown int    `json:"failures_to_down,omitempty"`
+	SuccessesToUp  int    `json:"successes_to_up,omitempty"`
+	Cooldown       string `json:"cooldown,omitempty"`
+}
+
+// SandboxSpec restricts how the supervisor launches a server: a working
+// directory jail, an allowlist of inherited environment variables, and
+// network isolation. Resource limits stay in ResourceLimits, enforced by
+// the supervise sweeps.
+type SandboxSpec struct {
+	Jail         string   `json:"jail,omitempty"`
+	EnvAllowlist []string `json:"env_allowlist,omitempty"`
+	NoNetwork    bool     `json:"no_network,omitempty"`
+}
+
+// ResourceLimits caps resource usage of a managed server process; exceeding
+// a limit triggers a restart when enforcement runs.
+type ResourceLimits struct {
+	MaxRSSMegabytes int `json:"max_rss_mb,omitempty"`
+	MaxCPUPercent   int `json:"max_cpu_percent,omitempty"`
+	MaxOpenFDs      int `json:"max_open_fds,omitempty"`
+}
+
+// MCPRegistry is the on-disk registry document. Revision increments on
+// every save and guards against concurrent writers clobbering each other.
+type MCPRegistry struct {
+	Version   string      `json:"version"`
+	Revision  int64       `json:"revision,omitempty"`
+	UpdatedAt string      `json:"updated_at,omitempty"`
+	Servers   []MCPServer `json:"servers"`
+}
+
+// findServer returns a pointer into the registry's server slice, or nil.
+func (r *MCPRegistry) findServer(name string) *MCPServer {
+	for i := range r.Servers {
+		if r.Servers[i].Name == name {
+			return &r.Servers[i]
+		}
+	}
+	return nil
+}
+
+// findMachinaRoot resolves the Machina repository root. MACHINA_ROOT wins
+// when set; otherwise the walk up from the working directory stops at a
+// directory containing mcp_status.json or a .machina marker file.
+func findMachinaRoot() (string, error) {
+	if root := os.Getenv("MACHINA_ROOT"); root != "" {
+		info, err := os.Stat(root)
+		if err != nil || !info.IsDir() {
+			return "", fmt.Errorf("MACHINA_ROOT %s is not a directory", root)
+		}
+		return root, nil
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, registryFileName)); err == nil {
+			return dir, nil
+		}
+		if _, err := os.Stat(filepath.Join(dir, machinaMarkerName)); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("machina root not found (set MACHINA_ROOT, add a %s marker file, or pass --registry)", machinaMarkerName)
+		}
+		dir = parent
+	}
+}
+
+// registryPath resolves the registry file location from --registry, the
+// registry_path config key, or the discovered machina root.
+func registryPath() (string, error) {
+	if registryFlag != "" {
+		return registryFlag, nil
+	}
+	if cfg, err := loadConfig(); err == nil && cfg.RegistryPath != "" {
+		return cfg.RegistryPath, nil
+	}
+	root, err := findMachinaRoot()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, registryFileName), nil
+}
+
+// loadMCPRegistry reads and parses the registry file.
+func loadMCPRegistry(path string) (*MCPRegistry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, wrapNamed(errRegistryNotFound, path)
+		}
+		return nil, fmt.Errorf("loading registry: %w", err)
+	}
+	var reg MCPRegistry
+	if err := json.Unmarshal(data, &reg); err != nil {
+		return nil, fmt.Errorf("parsing registry %s: %w", path, err)
+	}
+	return &reg, nil
+}
+
+// lockRegistry takes an exclusive advisory lock on a lock file beside the
+// registry, blocking until any other writer finishes. Closing the returned
+// file releases the lock. The lock file sits next to the registry rather
+// than being the registry itself, so the atomic rename in saveMCPRegistry
+// doesn't swap the locked inode out from under a waiting writer.
+func lockRegistry(path string) (*os.File, error) {
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("locking registry: %w", err)
+	}
+	if err := lockFileHandle(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("locking registry: %w", err)
+	}
+	return f, nil
+}
+
+// writeFileAtomic writes data to a temp file in the same directory and
+// renames it into place, so readers see either the old registry or the new
+// one but never a torn write.
+func writeFileAtomic(path string, data []byte, mode os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// saveMCPRegistry writes the registry back to disk. The save is rejected
+// when the on-disk revision no longer matches the one this registry was
+// loaded at: another writer got there first, and the caller should re-read
+// and retry rather than silently overwrite the concurrent edit. An
+// advisory lock held across the revision check and the write keeps two
+// writers from both passing the check, and the file is renamed into place
+// atomically so readers never see a partial write.
+func saveMCPRegistry(path string, reg *MCPRegistry) error {
+	lock, err := lockRegistry(path)
+	if err != nil {
+		return err
+	}
+	defer lock.Close()
+	if current, err := loadMCPRegistry(path); err == nil && current.Revision != reg.Revision {
+		return fmt.Errorf("%w (revision %d, now %d): re-read and retry",
+			errRegistryConflict, reg.Revision, current.Revision)
+	}
+	reg.Revision++
+	reg.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	data, err := json.MarshalIndent(reg, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dryRunFlag {
+		before, _ := os.ReadFile(path)
+		printDryRunDiff(path, string(before), string(data))
+		return nil
+	}
+	if before, err := os.ReadFile(path); err == nil {
+		recordRegistrySnapshot(path, before)
+	}
+	if err := writeFileAtomic(path, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	invalidateRegistryCache(path)
+	commitRegistryChange(path)
+	publishEvent("registry_saved", "", map[string]string{
+		"path":     path,
+		"revision": strconv.FormatInt(reg.Revision, 10),
+	})
+	return nil
+}
+
+// errRegistryConflict reports a save rejected by the revision check.
+var errRegistryConflict = errors.New("registry changed since it was read")
+
+// mutateServer patches a single server record: it loads the registry,
+// applies the patch, and saves, retrying on revision conflicts. Patches
+// that leave the record unchanged skip the write entirely, so read-mostly
+// callers don't rewrite the whole file for nothing.
+func mutateServer(path, name string, patch func(*MCPServer) error) error {
+	const maxAttempts = 3
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		srv := reg.findServer(name)
+		if srv == nil {
+			return serverNotFoundError(name)
+		}
+		before, err := json.Marshal(srv)
+		if err != nil {
+			return err
+		}
+		fromStatus := srv.Status
+		if err := patch(srv); err != nil {
+			return err
+		}
+		after, err := json.Marshal(srv)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(before, after) {
+			return nil
+		}
+		err = saveMCPRegistry(path, reg)
+		if err == nil {
+			if srv.Status != fromStatus {
+				fireStatusChange(name, fromStatus, srv.Status)
+			}
+			return nil
+		}
+		if !errors.Is(err, errRegistryConflict) {
+			return err
+		}
+		debugf("mutateServer: revision conflict on %s (attempt %d), retrying", name, attempt+1)
+	}
+	return fmt.Errorf("updating %s: registry kept changing after %d attempts", name, maxAttempts)
+}
+
+// launchCommand is a fully resolved way to start a stdio server.
+type launchCommand struct {
+	Command string
+	Args    []string
+	Dir     string
+	Env     map[string]string
+}
+
+// resolveLaunchCommand determines how to start a stdio server. A structured
+// launch spec in the registry takes precedence; otherwise the command is
+// derived from the legacy stdio:// endpoint path.
+func resolveLaunchCommand(srv *MCPServer) (*launchCommand, error) {
+	if err := verifyProvenance(srv); err != nil {
+		return nil, fmt.Errorf("refusing to launch: %w", err)
+	}
+	if srv.Launch != nil {
+		launch := &launchCommand{
+			Command: srv.Launch.Command,
+			Args:    append([]string(nil), srv.Launch.Args...),
+			Dir:     srv.Launch.Cwd,
+			Env:     srv.Launch.Env,
+		}
+		if srv.Launch.Interpreter != "" {
+			// An interpreter wraps the command: e.g. python3 + script path.
+			launch.Args = append([]string{launch.Command}, launch.Args...)
+			launch.Command = srv.Launch.Interpreter
+		}
+		if launch.Command == "" && srv.Launch.NpmPackage == "" {
+			return nil, fmt.Errorf("server %s has a launch spec without a command", srv.Name)
+		}
+		if err := applyPythonEnvironment(srv, launch); err != nil {
+			return nil, err
+		}
+		if err := applyNodeEnvironment(srv, launch); err != nil {
+			return nil, err
+		}
+		return launch, nil
+	}
+	return legacyStdioCommand(srv)
+}
+
+// launchEnviron builds the child process environment: the parent environment
+// plus any launch spec overrides.
+func launchEnviron(launch *launchCommand) []string {
+	env := os.Environ()
+	for key, value := range launch.Env {
+		env = append(env, key+"="+value)
+	}
+	return env
+}
+
+// legacyStdioCommand derives the command from a stdio:// endpoint recorded
+// by the Python registry, e.g. stdio://devqai/machina/src/surrealdb_mcp.py,
+// rewriting the path to live under the discovered machina root.
+func legacyStdioCommand(srv *MCPServer) (*launchCommand, error) {
+	if !strings.HasPrefix(srv.Endpoint, "stdio://") {
+		return nil, fmt.Errorf("server %s is not a stdio server (endpoint %s)", srv.Name, srv.Endpoint)
+	}
+	rel := strings.TrimPrefix(srv.Endpoint, "stdio://")
+	if idx := strings.Index(rel, "machina/"); idx >= 0 {
+		rel = rel[idx+len("machina/"):]
+	}
+	root, err := findMachinaRoot()
+	if err != nil {
+		return nil, err
+	}
+	script := filepath.Join(root, filepath.FromSlash(rel))
+	if _, err := os.Stat(script); err != nil {
+		return nil, fmt.Errorf("server script not found: %s", script)
+	}
+	return &launchCommand{Command: "python3", Args: []string{script}, Dir: root}, nil
+}
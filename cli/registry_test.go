@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.json")
+	if err := writeFileAtomic(path, []byte("first"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileAtomic(path, []byte("second"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "second" {
+		t.Errorf("content = %q, want %q", data, "second")
+	}
+
+	// No temp files may survive a successful rename.
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp-") {
+			t.Errorf("leftover temp file %s", entry.Name())
+		}
+	}
+}
+
+func TestSaveMCPRegistryConcurrentWriters(t *testing.T) {
+	const writers = 8
+	path := writeTestRegistry(t, t.TempDir(), writers)
+	start, err := loadMCPRegistry(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each writer updates its own server, retrying on revision conflicts
+	// the way mutateServer does. The lock serializes the revision check
+	// and write, so every update must land exactly once.
+	var wg sync.WaitGroup
+	failures := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("server-%04d", i)
+			for {
+				reg, err := loadMCPRegistry(path)
+				if err != nil {
+					failures <- err
+					return
+				}
+				reg.findServer(name).Status = "updated"
+				err = saveMCPRegistry(path, reg)
+				if err == nil {
+					return
+				}
+				if !errors.Is(err, errRegistryConflict) {
+					failures <- err
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(failures)
+	for err := range failures {
+		t.Error(err)
+	}
+
+	final, err := loadMCPRegistry(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := final.Revision, start.Revision+writers; got != want {
+		t.Errorf("revision = %d, want %d (one increment per writer)", got, want)
+	}
+	for i := 0; i < writers; i++ {
+		name := fmt.Sprintf("server-%04d", i)
+		if srv := final.findServer(name); srv == nil || srv.Status != "updated" {
+			t.Errorf("%s: update lost", name)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultRegistryURL is the devgen serve/daemon endpoint on this host.
+const defaultRegistryURL = "http://localhost:8642"
+
+var registryURLFlag string
+
+var registryCmd = &cobra.Command{
+	Use:   "registry",
+	Short: "Interact with the HTTP registry endpoint",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := rootPreRun(cmd); err != nil {
+			return err
+		}
+		// The registry_url config key overrides the built-in default but
+		// not an explicit --url or DEVGEN_REGISTRY_URL.
+		if cmd.Flags().Changed("url") {
+			return nil
+		}
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if cfg.RegistryURL != "" {
+			registryURLFlag = cfg.RegistryURL
+		}
+		return nil
+	},
+}
+
+var registryRegisterCmd = &cobra.Command{
+	Use:   "register <server>",
+	Short: "Register a local server record with the HTTP registry",
+	Long: `Reads the named server from the local registry file and POSTs it to the
+HTTP registry endpoint, so a file-edited entry propagates to the endpoint's
+view (and from there to every consumer of /servers).`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		srv := reg.findServer(args[0])
+		if srv == nil {
+			return serverNotFoundError(args[0])
+		}
+		if err := registerWithEndpoint(registryURLFlag, srv); err != nil {
+			return err
+		}
+		fmt.Printf("Registered %s with %s\n", srv.Name, registryURLFlag)
+		return nil
+	},
+}
+
+// registerWithEndpoint POSTs one local server record to the HTTP registry.
+func registerWithEndpoint(baseURL string, srv *MCPServer) error {
+	payload := registrationPayload{
+		Name:        srv.Name,
+		Endpoint:    srv.Endpoint,
+		Description: srv.Description,
+		Category:    srv.Category,
+		Version:     srv.Version,
+		Tools:       srv.Tools,
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	resp, err := outboundRetryPolicy().doHTTP(registryHTTPClient(), func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodPost, baseURL+"/register", bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
+	if err != nil {
+		return fmt.Errorf("registering %s: %w", srv.Name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("registering %s: %w: %s", srv.Name, errAuthFailed, resp.Status)
+	}
+	if resp.StatusCode != http.StatusOK {
+		detail, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("registering %s: %s: %s", srv.Name, resp.Status, bytes.TrimSpace(detail))
+	}
+	return nil
+}
+
+var registryDeregisterCmd = &cobra.Command{
+	Use:   "deregister <name>",
+	Short: "Remove a server from the HTTP registry",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := deregisterFromEndpoint(registryURLFlag, args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("Deregistered %s from %s\n", args[0], registryURLFlag)
+		return nil
+	},
+}
+
+// deregisterFromEndpoint removes one server from the HTTP registry.
+func deregisterFromEndpoint(baseURL, name string) error {
+	target := baseURL + "/deregister?name=" + url.QueryEscape(name)
+	resp, err := outboundRetryPolicy().doHTTP(registryHTTPClient(), func() (*http.Request, error) {
+		return http.NewRequest(http.MethodDelete, target, nil)
+	})
+	if err != nil {
+		return fmt.Errorf("deregistering %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("deregistering %s: %w: %s", name, errAuthFailed, resp.Status)
+	}
+	if resp.StatusCode != http.StatusOK {
+		detail, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("deregistering %s: %s: %s", name, resp.Status, bytes.TrimSpace(detail))
+	}
+	return nil
+}
+
+func registryHTTPClient() *http.Client {
+	return outboundHTTPClient(5 * time.Second)
+}
+
+func init() {
+	registryCmd.PersistentFlags().StringVar(&registryURLFlag, "url", defaultRegistryURL,
+		"HTTP registry endpoint base URL")
+	registryCmd.AddCommand(registryRegisterCmd, registryDeregisterCmd)
+	rootCmd.AddCommand(registryCmd)
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Registry status fetch behavior: retries with exponential backoff, and a
+// short-lived response cache so repeat commands don't re-poll the endpoint.
+const (
+	registryStatusAttempts  = 3
+	registryStatusBackoff   = 500 * time.Millisecond
+	registryStatusCacheTTL  = 30 * time.Second
+	registryStatusCacheFile = "devgen_registry_status_cache.json"
+)
+
+// registryStatusErrorCategory classifies a fetch failure so operators see
+// "DNS failure" instead of a generic wrapped error chain.
+func registryStatusErrorCategory(err error) string {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return "dns"
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return "refused"
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return "timeout"
+	}
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+		return "bad JSON"
+	}
+	return "other"
+}
+
+// registryStatusCache is the cached /servers response.
+type registryStatusCache struct {
+	FetchedAt string      `json:"fetched_at"`
+	URL       string      `json:"url"`
+	Servers   []MCPServer `json:"servers"`
+}
+
+func registryStatusCachePath() (string, error) {
+	path, err := registryPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), registryStatusCacheFile), nil
+}
+
+// loadRegistryStatusCache returns a cache entry for the URL within maxAge;
+// maxAge <= 0 accepts a snapshot of any age (offline fallback).
+func loadRegistryStatusCache(url string, maxAge time.Duration) *registryStatusCache {
+	path, err := registryStatusCachePath()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var cache registryStatusCache
+	if err := json.Unmarshal(data, &cache); err != nil || cache.URL != url {
+		return nil
+	}
+	fetched, err := time.Parse(time.RFC3339, cache.FetchedAt)
+	if err != nil {
+		return nil
+	}
+	if maxAge > 0 && time.Since(fetched) > maxAge {
+		return nil
+	}
+	return &cache
+}
+
+func saveRegistryStatusCache(cache *registryStatusCache) {
+	path, err := registryStatusCachePath()
+	if err != nil {
+		return
+	}
+	if data, err := json.Marshal(cache); err == nil {
+		os.WriteFile(path, append(data, '\n'), 0o644)
+	}
+}
+
+// checkRegistryStatus fetches /servers with retries and exponential
+// backoff, categorizing the final failure.
+func checkRegistryStatus(url string) ([]MCPServer, error) {
+	var lastErr error
+	backoff := registryStatusBackoff
+	for attempt := 0; attempt < registryStatusAttempts; attempt++ {
+		if attempt > 0 {
+			debugf("registry status: retrying %s in %s (attempt %d)", url, backoff, attempt+1)
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		servers, err := fetchRegistryServers(url)
+		if err == nil {
+			saveRegistryStatusCache(&registryStatusCache{
+				FetchedAt: time.Now().UTC().Format(time.RFC3339),
+				URL:       url,
+				Servers:   servers,
+			})
+			return servers, nil
+		}
+		lastErr = err
+		// Malformed responses won't improve with retries.
+		if registryStatusErrorCategory(err) == "bad JSON" {
+			break
+		}
+	}
+	return nil, fmt.Errorf("registry endpoint %s unreachable (%s): %w",
+		url, registryStatusErrorCategory(lastErr), lastErr)
+}
+
+func fetchRegistryServers(url string) ([]MCPServer, error) {
+	resp, err := registryHTTPClient().Get(url + "/servers")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	var servers []MCPServer
+	if err := json.NewDecoder(resp.Body).Decode(&servers); err != nil {
+		return nil, err
+	}
+	return servers, nil
+}
+
+var (
+	registryNoCacheFlag bool
+	registryOfflineFlag bool
+)
+
+// fetchServersWithFallback resolves the endpoint's server list honoring the
+// offline flags: --offline serves the last-known snapshot without touching
+// the network, and a failed fetch falls back to the snapshot (with a
+// staleness warning) unless --no-cache disables it.
+func fetchServersWithFallback(url string) ([]MCPServer, error) {
+	if registryOfflineFlag {
+		cache := loadRegistryStatusCache(url, 0)
+		if cache == nil {
+			return nil, fmt.Errorf("offline mode: no cached snapshot for %s", url)
+		}
+		fmt.Fprintf(os.Stderr, "offline mode: using snapshot from %s\n", cache.FetchedAt)
+		return cache.Servers, nil
+	}
+	servers, err := checkRegistryStatus(url)
+	if err == nil {
+		return servers, nil
+	}
+	if registryNoCacheFlag {
+		return nil, err
+	}
+	if cache := loadRegistryStatusCache(url, 0); cache != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: %v\nWARNING: falling back to snapshot from %s\n", err, cache.FetchedAt)
+		return cache.Servers, nil
+	}
+	return nil, err
+}
+
+var registryStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Check the HTTP registry endpoint",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !registryNoCacheFlag && !registryOfflineFlag {
+			if cache := loadRegistryStatusCache(registryURLFlag, registryStatusCacheTTL); cache != nil {
+				fmt.Printf("%s: reachable, %d server(s) (cached %s)\n",
+					registryURLFlag, len(cache.Servers), cache.FetchedAt)
+				return nil
+			}
+		}
+		servers, err := fetchServersWithFallback(registryURLFlag)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s: %d server(s)\n", registryURLFlag, len(servers))
+		return nil
+	},
+}
+
+var registryServersCmd = &cobra.Command{
+	Use:   "servers",
+	Short: "List servers known to the HTTP registry endpoint",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		servers, err := fetchServersWithFallback(registryURLFlag)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%-24s %-14s %-18s %6s  %s\n", "NAME", "CATEGORY", "STATUS", "TOOLS", "ENABLED")
+		for _, srv := range servers {
+			enabled := "no"
+			if srv.Enabled {
+				enabled = "yes"
+			}
+			fmt.Printf("%-24s %-14s %-18s %6d  %s\n",
+				srv.Name, srv.Category, srv.Status, len(srv.Tools), enabled)
+		}
+		return nil
+	},
+}
+
+func init() {
+	registryCmd.PersistentFlags().BoolVar(&registryNoCacheFlag, "no-cache", false,
+		"never use or fall back to the cached snapshot")
+	registryCmd.PersistentFlags().BoolVar(&registryOfflineFlag, "offline", false,
+		"use the last-known snapshot without contacting the endpoint")
+	registryCmd.AddCommand(registryStatusCmd, registryServersCmd)
+}
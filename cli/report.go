@@ -0,0 +1,213 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// reportServer is one row of the HTML report.
+type reportServer struct {
+	Name        string
+	Category    string
+	Status      string
+	Owner       string
+	Enabled     bool
+	ToolCount   int
+	Tools       []string
+	UptimePct   float64
+	HasUptime   bool
+	SLATarget   float64
+	MissedSLA   bool
+	Maintenance bool
+}
+
+// reportIncident is one damped down transition inside the window.
+type reportIncident struct {
+	Server string
+	At     string
+}
+
+// reportData feeds the HTML template. Uptime and incidents come from the
+// recorded health transition history; servers without history show
+// "no data" instead of an invented number.
+type reportData struct {
+	Version     string
+	GeneratedAt string
+	Window      string
+	Servers     []reportServer
+	Incidents   []reportIncident
+	Total       int
+	EnabledN    int
+	ActiveN     int
+	HasHistory  bool
+}
+
+// reportTemplate is a self-contained page: inline CSS, no scripts, no
+// external assets, so the file can be attached to a review or dropped on
+// a static site as-is. Uptime bars are plain styled divs.
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Machina MCP status report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", sans-serif; margin: 2rem auto; max-width: 60rem; color: #222; }
+h1 { font-size: 1.4rem; } h2 { font-size: 1.1rem; margin-top: 2rem; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 0.35rem 0.6rem; border-bottom: 1px solid #ddd; font-size: 0.9rem; }
+th { background: #f5f5f5; }
+.muted { color: #888; }
+.bad { color: #b00020; font-weight: 600; }
+.ok { color: #1b7a2f; }
+.bar { background: #eee; border-radius: 3px; height: 0.7rem; width: 10rem; display: inline-block; vertical-align: middle; }
+.bar > span { background: #1b7a2f; border-radius: 3px; height: 100%; display: block; }
+.bar.miss > span { background: #b00020; }
+footer { margin-top: 2rem; font-size: 0.8rem; color: #888; }
+</style>
+</head>
+<body>
+<h1>Machina MCP status report</h1>
+<p>Generated {{.GeneratedAt}} &middot; window {{.Window}} &middot;
+{{.Total}} servers, {{.EnabledN}} enabled, {{.ActiveN}} active</p>
+
+<h2>Servers</h2>
+<table>
+<tr><th>Name</th><th>Category</th><th>Status</th><th>Owner</th><th>Tools</th><th>Uptime ({{.Window}})</th></tr>
+{{range .Servers}}
+<tr>
+<td>{{.Name}}{{if .Maintenance}} <span class="muted">(maintenance)</span>{{end}}</td>
+<td>{{.Category}}</td>
+<td>{{if .Enabled}}{{.Status}}{{else}}<span class="muted">disabled</span>{{end}}</td>
+<td>{{if .Owner}}{{.Owner}}{{else}}<span class="muted">unowned</span>{{end}}</td>
+<td>{{.ToolCount}}</td>
+<td>{{if .HasUptime}}<span class="bar{{if .MissedSLA}} miss{{end}}"><span style="width: {{printf "%.1f" .UptimePct}}%"></span></span>
+{{printf "%.2f" .UptimePct}}%{{if .MissedSLA}} <span class="bad">missed {{printf "%.2f" .SLATarget}}% target</span>{{else if gt .SLATarget 0.0}} <span class="ok">target {{printf "%.2f" .SLATarget}}%</span>{{end}}
+{{else}}<span class="muted">no data</span>{{end}}</td>
+</tr>
+{{end}}
+</table>
+
+<h2>Tool inventory</h2>
+<table>
+<tr><th>Server</th><th>Tools</th></tr>
+{{range .Servers}}{{if .Tools}}
+<tr><td>{{.Name}}</td><td>{{range $i, $t := .Tools}}{{if $i}}, {{end}}{{$t}}{{end}}</td></tr>
+{{end}}{{end}}
+</table>
+
+<h2>Recent incidents</h2>
+{{if .Incidents}}
+<table>
+<tr><th>Server</th><th>Went down at</th></tr>
+{{range .Incidents}}<tr><td>{{.Server}}</td><td>{{.At}}</td></tr>
+{{end}}
+</table>
+{{else if .HasHistory}}
+<p class="ok">No down transitions recorded in the window.</p>
+{{else}}
+<p class="muted">No health history recorded yet; run 'devgen daemon' to collect it.</p>
+{{end}}
+
+<footer>Generated by devgen {{.Version}}</footer>
+</body>
+</html>
+`))
+
+var (
+	reportOutputFlag string
+	reportWindowFlag time.Duration
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate a static HTML status report",
+	Long: `Writes a self-contained HTML page summarizing the registry: server
+status, per-server uptime over the window (from the damped health
+transition history), tool inventory, and recent down incidents. Servers
+without recorded history show "no data" rather than a fabricated uptime.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		transitions, err := loadHealthTransitions()
+		if err != nil {
+			return err
+		}
+
+		to := time.Now()
+		from := to.Add(-reportWindowFlag)
+		data := reportData{
+			Version:     devgenVersion,
+			GeneratedAt: to.UTC().Format(time.RFC3339),
+			Window:      reportWindowFlag.String(),
+			Total:       len(reg.Servers),
+			HasHistory:  len(transitions) > 0,
+		}
+		for i := range reg.Servers {
+			srv := &reg.Servers[i]
+			row := reportServer{
+				Name:        srv.Name,
+				Category:    srv.Category,
+				Status:      srv.Status,
+				Owner:       srv.Owner,
+				Enabled:     srv.Enabled,
+				ToolCount:   len(srv.Tools),
+				Tools:       srv.Tools,
+				SLATarget:   srv.SLATarget,
+				Maintenance: inMaintenance(srv),
+			}
+			if srv.Enabled {
+				data.EnabledN++
+			}
+			if srv.Status == "active" {
+				data.ActiveN++
+			}
+			if fraction, ok := uptimeFraction(transitions, srv.Name, from, to); ok {
+				row.HasUptime = true
+				row.UptimePct = fraction * 100
+				row.MissedSLA = srv.SLATarget > 0 && row.UptimePct < srv.SLATarget
+			}
+			data.Servers = append(data.Servers, row)
+		}
+		sort.Slice(data.Servers, func(a, b int) bool { return data.Servers[a].Name < data.Servers[b].Name })
+
+		for _, tr := range transitions {
+			if tr.State != "down" {
+				continue
+			}
+			at, err := time.Parse(time.RFC3339, tr.At)
+			if err != nil || at.Before(from) || at.After(to) {
+				continue
+			}
+			data.Incidents = append(data.Incidents, reportIncident{Server: tr.Server, At: tr.At})
+		}
+		sort.Slice(data.Incidents, func(a, b int) bool { return data.Incidents[a].At > data.Incidents[b].At })
+
+		f, err := os.Create(reportOutputFlag)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if err := reportTemplate.Execute(f, data); err != nil {
+			return fmt.Errorf("rendering report: %w", err)
+		}
+		fmt.Printf("Wrote %s (%d servers, %d incidents)\n", reportOutputFlag, len(data.Servers), len(data.Incidents))
+		return nil
+	},
+}
+
+func init() {
+	reportCmd.Flags().StringVarP(&reportOutputFlag, "output", "o", "machina-report.html", "output file path")
+	reportCmd.Flags().DurationVar(&reportWindowFlag, "window", 7*24*time.Hour, "uptime and incident window")
+	rootCmd.AddCommand(reportCmd)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Restart policy names, mirroring systemd/docker semantics.
+const (
+	restartNever     = "never"
+	restartOnFailure = "on-failure"
+	restartAlways    = "always"
+)
+
+// RestartPolicy configures how the supervisor reacts when a managed server
+// exits. Backoff doubles after each recent restart, starting at
+// BackoffSeconds and capped at BackoffMaxSeconds.
+type RestartPolicy struct {
+	Policy            string `json:"policy"`
+	BackoffSeconds    int    `json:"backoff_seconds,omitempty"`
+	BackoffMaxSeconds int    `json:"backoff_max_seconds,omitempty"`
+}
+
+// Policy and backoff defaults when the registry leaves them unset.
+const (
+	defaultRestartBackoff    = 2 * time.Second
+	defaultRestartBackoffMax = time.Minute
+)
+
+// restartPolicyFor returns the effective policy for a server; servers
+// without one are never restarted automatically.
+func restartPolicyFor(srv *MCPServer) string {
+	if srv.Restart == nil || srv.Restart.Policy == "" {
+		return restartNever
+	}
+	return srv.Restart.Policy
+}
+
+// validateRestartPolicy rejects unknown policy names at load time.
+func validateRestartPolicy(srv *MCPServer) error {
+	policy := restartPolicyFor(srv)
+	switch policy {
+	case restartNever, restartOnFailure, restartAlways:
+		return nil
+	}
+	return fmt.Errorf("server %s has unknown restart policy %q (want never, on-failure, or always)",
+		srv.Name, policy)
+}
+
+// shouldAutoRestart reports whether an exited managed server should be
+// relaunched. Detached processes don't report exit status, so on-failure is
+// treated like always: any unexpected exit counts as a failure.
+func shouldAutoRestart(srv *MCPServer) bool {
+	switch restartPolicyFor(srv) {
+	case restartOnFailure, restartAlways:
+		return srv.Status != statusQuarantined
+	}
+	return false
+}
+
+// restartBackoff computes the delay before the next restart attempt, doubling
+// per recent restart up to the configured maximum.
+func restartBackoff(srv *MCPServer, recentRestarts int) time.Duration {
+	initial := defaultRestartBackoff
+	max := defaultRestartBackoffMax
+	if srv.Restart != nil {
+		if srv.Restart.BackoffSeconds > 0 {
+			initial = time.Duration(srv.Restart.BackoffSeconds) * time.Second
+		}
+		if srv.Restart.BackoffMaxSeconds > 0 {
+			max = time.Duration(srv.Restart.BackoffMaxSeconds) * time.Second
+		}
+	}
+	delay := initial
+	for i := 0; i < recentRestarts && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	return delay
+}
+
+// backoffElapsed reports whether enough time has passed since the last
+// restart for another attempt.
+func backoffElapsed(srv *MCPServer, proc managedProcess) bool {
+	if len(proc.Restarts) == 0 {
+		return true
+	}
+	last, err := time.Parse(time.RFC3339, proc.Restarts[len(proc.Restarts)-1])
+	if err != nil {
+		return true
+	}
+	return time.Since(last) >= restartBackoff(srv, len(proc.Restarts))
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Outbound calls share one retry policy, configured once and applied the
+// same way by the registry client, health probes, and tool calls. The
+// default is a single attempt — identical to the old behavior — so
+// retries only happen when the config asks for them.
+
+// retryConfig is the config block for outbound retry behavior. Backoff
+// values are Go duration strings; jitter is a fraction of the backoff
+// (0.2 means +/-20%).
+type retryConfig struct {
+	MaxAttempts       int     `json:"max_attempts,omitempty"`
+	Backoff           string  `json:"backoff,omitempty"`
+	MaxBackoff        string  `json:"max_backoff,omitempty"`
+	Jitter            float64 `json:"jitter,omitempty"`
+	RetryableStatuses []int   `json:"retryable_statuses,omitempty"`
+}
+
+// retryPolicy is the parsed, ready-to-use form of retryConfig.
+type retryPolicy struct {
+	attempts int
+	backoff  time.Duration
+	maxWait  time.Duration
+	jitter   float64
+	statuses map[int]bool
+}
+
+// defaultRetryableStatuses are the codes worth retrying when the config
+// enables retries without naming its own set.
+var defaultRetryableStatuses = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+func parseRetryPolicy(cfg retryConfig) (retryPolicy, error) {
+	policy := retryPolicy{
+		attempts: 1,
+		backoff:  500 * time.Millisecond,
+		maxWait:  10 * time.Second,
+		jitter:   cfg.Jitter,
+		statuses: map[int]bool{},
+	}
+	if cfg.MaxAttempts > 0 {
+		policy.attempts = cfg.MaxAttempts
+	}
+	if cfg.Backoff != "" {
+		d, err := time.ParseDuration(cfg.Backoff)
+		if err != nil {
+			return policy, fmt.Errorf("config retry.backoff: %w", err)
+		}
+		policy.backoff = d
+	}
+	if cfg.MaxBackoff != "" {
+		d, err := time.ParseDuration(cfg.MaxBackoff)
+		if err != nil {
+			return policy, fmt.Errorf("config retry.max_backoff: %w", err)
+		}
+		policy.maxWait = d
+	}
+	if policy.jitter < 0 || policy.jitter > 1 {
+		return policy, fmt.Errorf("config retry.jitter: %v is not in [0, 1]", policy.jitter)
+	}
+	codes := cfg.RetryableStatuses
+	if len(codes) == 0 {
+		codes = defaultRetryableStatuses
+	}
+	for _, code := range codes {
+		policy.statuses[code] = true
+	}
+	return policy, nil
+}
+
+// loadRetryPolicy reads the policy from config, falling back to the
+// single-attempt default when the config cannot be read or parsed.
+func loadRetryPolicy() retryPolicy {
+	cfg, err := loadConfig()
+	if err != nil {
+		policy, _ := parseRetryPolicy(retryConfig{})
+		return policy
+	}
+	policy, err := parseRetryPolicy(cfg.Retry)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: %v (retries disabled)\n", err)
+		policy, _ = parseRetryPolicy(retryConfig{})
+	}
+	return policy
+}
+
+// wait returns the backoff before attempt n (1-based), doubling each time
+// up to the cap, with the configured jitter applied.
+func (p retryPolicy) wait(attempt int) time.Duration {
+	d := p.backoff
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= p.maxWait {
+			d = p.maxWait
+			break
+		}
+	}
+	if p.jitter > 0 {
+		spread := float64(d) * p.jitter
+		d += time.Duration((rand.Float64()*2 - 1) * spread)
+	}
+	if d < 0 {
+		d = 0
+	}
+	return d
+}
+
+// retryableStatus reports whether an HTTP status should trigger another
+// attempt under this policy.
+func (p retryPolicy) retryableStatus(code int) bool {
+	return p.statuses[code]
+}
+
+// doHTTP performs one logical HTTP request under the policy. build runs
+// per attempt so request bodies are fresh each time. Responses with a
+// retryable status are closed and retried while budget remains; the last
+// attempt's response is always returned so callers keep their own status
+// handling.
+func (p retryPolicy) doHTTP(client *http.Client, build func() (*http.Request, error)) (*http.Response, error) {
+	var lastErr error
+	for attempt := 1; attempt <= p.attempts; attempt++ {
+		req, err := build()
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		switch {
+		case err != nil:
+			lastErr = err
+			if attempt == p.attempts {
+				return nil, lastErr
+			}
+		case p.retryableStatus(resp.StatusCode) && attempt < p.attempts:
+			resp.Body.Close()
+		default:
+			return resp, nil
+		}
+		time.Sleep(p.wait(attempt))
+	}
+	return nil, lastErr
+}
+
+// do runs op up to the policy's attempt budget. op reports whether its
+// failure is retryable; non-retryable failures and successes return
+// immediately. The last error wins.
+func (p retryPolicy) do(op func() (retryable bool, err error)) error {
+	var lastErr error
+	for attempt := 1; attempt <= p.attempts; attempt++ {
+		retryable, err := op()
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retryable || attempt == p.attempts {
+			return lastErr
+		}
+		time.Sleep(p.wait(attempt))
+	}
+	return lastErr
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseRetryPolicy(t *testing.T) {
+	policy, err := parseRetryPolicy(retryConfig{})
+	if err != nil {
+		t.Fatalf("default config: %v", err)
+	}
+	if policy.attempts != 1 {
+		t.Errorf("default attempts = %d, want 1 (no behavior change)", policy.attempts)
+	}
+	if !policy.retryableStatus(http.StatusServiceUnavailable) || policy.retryableStatus(http.StatusNotFound) {
+		t.Error("default retryable statuses wrong")
+	}
+
+	policy, err = parseRetryPolicy(retryConfig{
+		MaxAttempts:       4,
+		Backoff:           "100ms",
+		MaxBackoff:        "300ms",
+		RetryableStatuses: []int{http.StatusConflict},
+	})
+	if err != nil {
+		t.Fatalf("custom config: %v", err)
+	}
+	if policy.attempts != 4 {
+		t.Errorf("attempts = %d", policy.attempts)
+	}
+	if !policy.retryableStatus(http.StatusConflict) || policy.retryableStatus(http.StatusServiceUnavailable) {
+		t.Error("custom statuses should replace the defaults")
+	}
+	// Exponential backoff doubles and then caps.
+	if got := policy.wait(1); got != 100*time.Millisecond {
+		t.Errorf("wait(1) = %s", got)
+	}
+	if got := policy.wait(2); got != 200*time.Millisecond {
+		t.Errorf("wait(2) = %s", got)
+	}
+	if got := policy.wait(5); got != 300*time.Millisecond {
+		t.Errorf("wait(5) = %s, want capped at 300ms", got)
+	}
+
+	if _, err := parseRetryPolicy(retryConfig{Backoff: "soon"}); err == nil {
+		t.Error("bad backoff duration should fail")
+	}
+	if _, err := parseRetryPolicy(retryConfig{Jitter: 1.5}); err == nil {
+		t.Error("jitter outside [0,1] should fail")
+	}
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	policy, _ := parseRetryPolicy(retryConfig{MaxAttempts: 3, Backoff: "1ms"})
+
+	calls := 0
+	err := policy.do(func() (bool, error) {
+		calls++
+		if calls < 3 {
+			return true, fmt.Errorf("transient %d", calls)
+		}
+		return false, nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("retryable failures: err=%v calls=%d", err, calls)
+	}
+
+	calls = 0
+	err = policy.do(func() (bool, error) {
+		calls++
+		return false, fmt.Errorf("permanent")
+	})
+	if err == nil || calls != 1 {
+		t.Errorf("non-retryable failure should not retry: err=%v calls=%d", err, calls)
+	}
+
+	calls = 0
+	err = policy.do(func() (bool, error) {
+		calls++
+		return true, fmt.Errorf("always failing")
+	})
+	if err == nil || calls != 3 {
+		t.Errorf("budget exhaustion: err=%v calls=%d", err, calls)
+	}
+}
+
+func TestRetryPolicyDoHTTP(t *testing.T) {
+	var hits atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	policy, _ := parseRetryPolicy(retryConfig{MaxAttempts: 3, Backoff: "1ms"})
+	resp, err := policy.doHTTP(srv.Client(), func() (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, srv.URL, nil)
+	})
+	if err != nil {
+		t.Fatalf("doHTTP: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d after retries", resp.StatusCode)
+	}
+	if hits.Load() != 3 {
+		t.Errorf("server saw %d requests, want 3", hits.Load())
+	}
+
+	// A single-attempt policy passes the 503 straight through: callers
+	// keep their own status handling, exactly as without retries.
+	hits.Store(0)
+	single, _ := parseRetryPolicy(retryConfig{})
+	resp, err = single.doHTTP(srv.Client(), func() (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, srv.URL, nil)
+	})
+	if err != nil {
+		t.Fatalf("single attempt: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("single attempt status = %d, want 503 passed through", resp.StatusCode)
+	}
+	if hits.Load() != 1 {
+		t.Errorf("server saw %d requests, want 1", hits.Load())
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// sensitiveRootWarning reports why a configured filesystem root is risky,
+// or "" when it looks fine. Flagged: the filesystem root itself, the home
+// directory or any parent of it, and system configuration directories.
+func sensitiveRootWarning(root string) string {
+	expanded := root
+	if strings.HasPrefix(expanded, "~") {
+		if home, err := os.UserHomeDir(); err == nil {
+			expanded = home + expanded[1:]
+		}
+	}
+	expanded = filepath.Clean(os.ExpandEnv(expanded))
+	if expanded == "/" || expanded == filepath.VolumeName(expanded)+string(os.PathSeparator) {
+		return "grants access to the entire filesystem"
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		if expanded == home {
+			return "grants access to the whole home directory"
+		}
+		if rel, err := filepath.Rel(expanded, home); err == nil && !strings.HasPrefix(rel, "..") {
+			return "contains the home directory"
+		}
+	}
+	switch expanded {
+	case "/etc", "/var", "/usr", "/root":
+		return "sensitive system path"
+	}
+	return ""
+}
+
+// printRoots renders a server's filesystem roots with warnings, using the
+// given indent for list items.
+func printRoots(srv *MCPServer, indent string) {
+	for _, root := range srv.Roots {
+		if warning := sensitiveRootWarning(root); warning != "" {
+			fmt.Printf("%s- %s  (WARNING: %s)\n", indent, root, warning)
+		} else {
+			fmt.Printf("%s- %s\n", indent, root)
+		}
+	}
+}
+
+var serverRootsCmd = &cobra.Command{
+	Use:   "roots <server> [dir...]",
+	Short: "Show or set a server's allowed filesystem roots",
+	Long: `Without directories, lists the filesystem roots recorded for the server.
+With directories, replaces the recorded roots. Roots pointing at sensitive
+paths (/, the home directory, system directories) are flagged.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		if len(args) == 1 {
+			reg, err := loadRegistryPreferDaemon(path)
+			if err != nil {
+				return err
+			}
+			srv := reg.findServer(args[0])
+			if srv == nil {
+				return serverNotFoundError(args[0])
+			}
+			if len(srv.Roots) == 0 {
+				fmt.Printf("%s has no filesystem roots recorded\n", srv.Name)
+				return nil
+			}
+			printRoots(srv, "")
+			return nil
+		}
+		roots := args[1:]
+		for _, root := range roots {
+			if warning := sensitiveRootWarning(root); warning != "" {
+				fmt.Printf("WARNING: %s %s\n", root, warning)
+			}
+		}
+		return mutateServer(path, args[0], func(srv *MCPServer) error {
+			srv.Roots = append([]string(nil), roots...)
+			return nil
+		})
+	},
+}
+
+func init() {
+	serverCmd.AddCommand(serverRootsCmd)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// applySandbox rewrites a launch command according to the server's sandbox
+// spec. It returns the (possibly wrapped) argv and the filtered
+// environment; servers without a sandbox pass through unchanged.
+func applySandbox(srv *MCPServer, command string, args []string, env []string) (string, []string, []string, error) {
+	spec := srv.Sandbox
+	if spec == nil {
+		return command, args, env, nil
+	}
+	if len(spec.EnvAllowlist) > 0 {
+		env = filterEnvAllowlist(env, spec.EnvAllowlist)
+	}
+	if spec.NoNetwork {
+		wrapped, wrappedArgs, err := noNetworkCommand(command, args)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("sandboxing %s: %w", srv.Name, err)
+		}
+		command, args = wrapped, wrappedArgs
+	}
+	return command, args, env, nil
+}
+
+// filterEnvAllowlist keeps only the allowlisted variables.
+func filterEnvAllowlist(env, allow []string) []string {
+	allowed := make(map[string]bool, len(allow))
+	for _, name := range allow {
+		allowed[name] = true
+	}
+	var kept []string
+	for _, entry := range env {
+		name, _, ok := strings.Cut(entry, "=")
+		if ok && allowed[name] {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+// noNetworkCommand wraps argv in the platform's network isolation tool:
+// unshare on Linux, sandbox-exec on macOS. Missing tooling is an error
+// rather than a silent un-sandboxed launch.
+func noNetworkCommand(command string, args []string) (string, []string, error) {
+	switch runtime.GOOS {
+	case "linux":
+		if _, err := exec.LookPath("unshare"); err != nil {
+			return "", nil, fmt.Errorf("no_network requires unshare: %w", err)
+		}
+		return "unshare", append([]string{"--net", "--map-root-user", "--", command}, args...), nil
+	case "darwin":
+		if _, err := exec.LookPath("sandbox-exec"); err != nil {
+			return "", nil, fmt.Errorf("no_network requires sandbox-exec: %w", err)
+		}
+		profile := "(version 1)(allow default)(deny network*)"
+		return "sandbox-exec", append([]string{"-p", profile, command}, args...), nil
+	default:
+		return "", nil, fmt.Errorf("no_network is not supported on %s", runtime.GOOS)
+	}
+}
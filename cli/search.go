@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Score weights per matched field; names matter most, descriptions least.
+const (
+	scoreNameExact   = 100
+	scoreNameSub     = 50
+	scoreToolExact   = 40
+	scoreToolSub     = 20
+	scoreCategory    = 15
+	scoreDescription = 10
+)
+
+// searchResult pairs a server with its relevance score and what matched.
+type searchResult struct {
+	server  *MCPServer
+	score   int
+	matched []string
+}
+
+// scoreServer ranks one server against the query terms. Every term must
+// match at least one field, mirroring AND semantics; the score sums the
+// best field match per term.
+func scoreServer(srv *MCPServer, terms []string) (int, []string) {
+	total := 0
+	var matched []string
+	for _, term := range terms {
+		best := 0
+		field := ""
+		name := strings.ToLower(srv.Name)
+		switch {
+		case name == term:
+			best, field = scoreNameExact, "name"
+		case strings.Contains(name, term):
+			best, field = scoreNameSub, "name"
+		}
+		for _, tool := range srv.Tools {
+			lower := strings.ToLower(tool)
+			if lower == term && best < scoreToolExact {
+				best, field = scoreToolExact, "tool:"+tool
+			} else if strings.Contains(lower, term) && best < scoreToolSub {
+				best, field = scoreToolSub, "tool:"+tool
+			}
+		}
+		if strings.Contains(strings.ToLower(srv.Category), term) && best < scoreCategory {
+			best, field = scoreCategory, "category"
+		}
+		if strings.Contains(strings.ToLower(srv.Description), term) && best < scoreDescription {
+			best, field = scoreDescription, "description"
+		}
+		if best == 0 {
+			return 0, nil
+		}
+		total += best
+		matched = append(matched, field)
+	}
+	return total, matched
+}
+
+// searchServers ranks all matching servers, best first.
+func searchServers(reg *MCPRegistry, query string) []searchResult {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return nil
+	}
+	var results []searchResult
+	for i := range reg.Servers {
+		srv := &reg.Servers[i]
+		score, matched := scoreServer(srv, terms)
+		if score > 0 {
+			results = append(results, searchResult{server: srv, score: score, matched: matched})
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].score != results[j].score {
+			return results[i].score > results[j].score
+		}
+		return results[i].server.Name < results[j].server.Name
+	})
+	return results
+}
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query...>",
+	Short: "Search servers, tools, and descriptions",
+	Long: `Ranks servers against the query across names, tool names, categories,
+and descriptions, so "which server has a web scraping tool?" is one
+command: devgen search scraping.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadRegistryPreferDaemon(path)
+		if err != nil {
+			return err
+		}
+		results := searchServers(reg, strings.Join(args, " "))
+		if len(results) == 0 {
+			fmt.Println("No servers matched.")
+			return nil
+		}
+		fmt.Printf("%-24s %6s %-30s %s\n", "NAME", "SCORE", "MATCHED", "DESCRIPTION")
+		for _, result := range results {
+			fmt.Printf("%-24s %6d %-30s %s\n",
+				result.server.Name, result.score,
+				strings.Join(result.matched, ","),
+				firstLine(result.server.Description))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// selectorRequirement is one clause of a label selector.
+type selectorRequirement struct {
+	key      string
+	operator string // "=", "!=", "exists", "!exists"
+	value    string
+}
+
+// labelSelector filters servers by their tags, modeled on kubectl label
+// selectors: "env=prod,team=ml,tier!=dev,gpu,!deprecated".
+type labelSelector []selectorRequirement
+
+// parseSelector parses a comma-separated selector expression.
+func parseSelector(expr string) (labelSelector, error) {
+	var selector labelSelector
+	for _, clause := range strings.Split(expr, ",") {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			continue
+		}
+		switch {
+		case strings.Contains(clause, "!="):
+			parts := strings.SplitN(clause, "!=", 2)
+			if parts[0] == "" {
+				return nil, fmt.Errorf("invalid selector clause %q", clause)
+			}
+			selector = append(selector, selectorRequirement{key: parts[0], operator: "!=", value: parts[1]})
+		case strings.Contains(clause, "="):
+			parts := strings.SplitN(clause, "=", 2)
+			if parts[0] == "" {
+				return nil, fmt.Errorf("invalid selector clause %q", clause)
+			}
+			selector = append(selector, selectorRequirement{key: parts[0], operator: "=", value: parts[1]})
+		case strings.HasPrefix(clause, "!"):
+			if len(clause) == 1 {
+				return nil, fmt.Errorf("invalid selector clause %q", clause)
+			}
+			selector = append(selector, selectorRequirement{key: clause[1:], operator: "!exists"})
+		default:
+			selector = append(selector, selectorRequirement{key: clause, operator: "exists"})
+		}
+	}
+	return selector, nil
+}
+
+// matches reports whether the tags satisfy every selector clause.
+func (s labelSelector) matches(tags map[string]string) bool {
+	for _, req := range s {
+		value, present := tags[req.key]
+		switch req.operator {
+		case "=":
+			if !present || value != req.value {
+				return false
+			}
+		case "!=":
+			if present && value == req.value {
+				return false
+			}
+		case "exists":
+			if !present {
+				return false
+			}
+		case "!exists":
+			if present {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// filterServersBySelector returns the servers whose tags match.
+func filterServersBySelector(servers []MCPServer, selector labelSelector) []MCPServer {
+	if len(selector) == 0 {
+		return servers
+	}
+	var matched []MCPServer
+	for _, srv := range servers {
+		if selector.matches(srv.Tags) {
+			matched = append(matched, srv)
+		}
+	}
+	return matched
+}
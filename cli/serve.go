@@ -0,0 +1,278 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddrFlag       string
+	serveStaleAfterFlag time.Duration
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run the devgen HTTP endpoint for server self-registration",
+	Long: `Serves an HTTP API that MCP servers can use to self-register and
+heartbeat on startup:
+
+  POST /register   {"name": ..., "endpoint": ..., "tools": [...], ...}
+  POST /heartbeat  {"name": ...}
+  GET  /servers
+
+Registered entries are created or refreshed in the registry file. Servers
+whose heartbeats stop arriving are marked stale after --stale-after.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		receiver := &webhookReceiver{registryPath: path}
+		go receiver.sweepStale()
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/register", receiver.handleRegister)
+		mux.HandleFunc("/heartbeat", receiver.handleHeartbeat)
+		mux.HandleFunc("/deregister", receiver.handleDeregister)
+		mux.HandleFunc("/servers", receiver.handleServers)
+		mux.HandleFunc("/registry", receiver.handleRegistry)
+		fmt.Printf("devgen serve listening on %s (registry: %s)\n", serveAddrFlag, path)
+		return http.ListenAndServe(serveAddrFlag, mux)
+	},
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddrFlag, "addr", ":8642", "listen address")
+	serveCmd.Flags().DurationVar(&serveStaleAfterFlag, "stale-after", 2*time.Minute, "mark servers stale after this long without a heartbeat")
+	rootCmd.AddCommand(serveCmd)
+}
+
+// webhookReceiver handles self-registration and heartbeats, serializing
+// registry writes.
+type webhookReceiver struct {
+	registryPath string
+	mu           sync.Mutex
+}
+
+// registrationPayload is the body accepted by /register and /heartbeat.
+type registrationPayload struct {
+	Name        string   `json:"name"`
+	Endpoint    string   `json:"endpoint,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Category    string   `json:"category,omitempty"`
+	Version     string   `json:"version,omitempty"`
+	Tools       []string `json:"tools,omitempty"`
+}
+
+func (w *webhookReceiver) handleRegister(rw http.ResponseWriter, r *http.Request) {
+	var payload registrationPayload
+	if !decodeWebhookBody(rw, r, &payload) {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	reg, err := loadMCPRegistry(w.registryPath)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if payload.Category != "" && !knownCategory(reg, payload.Category) {
+		http.Error(rw, fmt.Sprintf(
+			"unknown category %q: use an existing category (see 'devgen category list') or add it via the taxonomy commands",
+			payload.Category), http.StatusBadRequest)
+		return
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	srv := reg.findServer(payload.Name)
+	created := srv == nil
+	if created {
+		reg.Servers = append(reg.Servers, MCPServer{Name: payload.Name, Enabled: true})
+		srv = &reg.Servers[len(reg.Servers)-1]
+	}
+	if payload.Endpoint != "" {
+		srv.Endpoint = payload.Endpoint
+	}
+	if payload.Description != "" {
+		srv.Description = payload.Description
+	}
+	if payload.Category != "" {
+		srv.Category = payload.Category
+	}
+	if payload.Version != "" {
+		srv.Version = payload.Version
+	}
+	if len(payload.Tools) > 0 {
+		srv.Tools = payload.Tools
+	}
+	srv.Status = "active"
+	srv.LastHeartbeat = now
+	if err := saveMCPRegistry(w.registryPath, reg); err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	debugf("serve: registered %s (created=%v)", payload.Name, created)
+	writeJSON(rw, map[string]interface{}{"registered": payload.Name, "created": created})
+}
+
+func (w *webhookReceiver) handleHeartbeat(rw http.ResponseWriter, r *http.Request) {
+	var payload registrationPayload
+	if !decodeWebhookBody(rw, r, &payload) {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	reg, err := loadMCPRegistry(w.registryPath)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	srv := reg.findServer(payload.Name)
+	if srv == nil {
+		http.Error(rw, "unknown server; POST /register first", http.StatusNotFound)
+		return
+	}
+	srv.LastHeartbeat = time.Now().UTC().Format(time.RFC3339)
+	if srv.Status == "stale" {
+		srv.Status = "active"
+	}
+	if err := saveMCPRegistry(w.registryPath, reg); err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(rw, map[string]interface{}{"ok": true})
+}
+
+// handleDeregister removes a server from the registry.
+func (w *webhookReceiver) handleDeregister(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		http.Error(rw, "POST or DELETE only", http.StatusMethodNotAllowed)
+		return
+	}
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		var payload registrationPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err == nil {
+			name = payload.Name
+		}
+	}
+	if name == "" {
+		http.Error(rw, "invalid payload: name is required", http.StatusBadRequest)
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	reg, err := loadMCPRegistry(w.registryPath)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	kept := reg.Servers[:0]
+	removed := false
+	for _, srv := range reg.Servers {
+		if srv.Name == name {
+			removed = true
+			continue
+		}
+		kept = append(kept, srv)
+	}
+	if !removed {
+		http.Error(rw, "unknown server: "+name, http.StatusNotFound)
+		return
+	}
+	reg.Servers = kept
+	if err := saveMCPRegistry(w.registryPath, reg); err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	debugf("serve: deregistered %s", name)
+	writeJSON(rw, map[string]interface{}{"deregistered": name})
+}
+
+// handleRegistry returns the whole registry document, used by the unix
+// socket control plane.
+func (w *webhookReceiver) handleRegistry(rw http.ResponseWriter, r *http.Request) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	reg, err := loadMCPRegistry(w.registryPath)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(rw, reg)
+}
+
+func (w *webhookReceiver) handleServers(rw http.ResponseWriter, r *http.Request) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	reg, err := loadMCPRegistry(w.registryPath)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	servers := reg.Servers
+	if expr := r.URL.Query().Get("selector"); expr != "" {
+		selector, err := parseSelector(expr)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		servers = filterServersBySelector(servers, selector)
+	}
+	writeJSON(rw, servers)
+}
+
+// sweepStale periodically marks servers stale when their heartbeats stop.
+func (w *webhookReceiver) sweepStale() {
+	for {
+		time.Sleep(serveStaleAfterFlag / 2)
+		w.mu.Lock()
+		reg, err := loadMCPRegistry(w.registryPath)
+		if err == nil {
+			changed := false
+			for i := range reg.Servers {
+				srv := &reg.Servers[i]
+				if srv.LastHeartbeat == "" || srv.Status == "stale" {
+					continue
+				}
+				beat, err := time.Parse(time.RFC3339, srv.LastHeartbeat)
+				if err != nil {
+					continue
+				}
+				if time.Since(beat) > serveStaleAfterFlag {
+					srv.Status = "stale"
+					changed = true
+					debugf("serve: marked %s stale (last heartbeat %s)", srv.Name, srv.LastHeartbeat)
+				}
+			}
+			if changed {
+				saveMCPRegistry(w.registryPath, reg)
+			}
+		}
+		w.mu.Unlock()
+	}
+}
+
+func decodeWebhookBody(rw http.ResponseWriter, r *http.Request, payload *registrationPayload) bool {
+	if r.Method != http.MethodPost {
+		http.Error(rw, "POST only", http.StatusMethodNotAllowed)
+		return false
+	}
+	if err := json.NewDecoder(r.Body).Decode(payload); err != nil || payload.Name == "" {
+		http.Error(rw, "invalid payload: name is required", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func writeJSON(rw http.ResponseWriter, value interface{}) {
+	rw.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(rw).Encode(value)
+}
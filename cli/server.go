@@ -0,0 +1,286 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var serverCmd = &cobra.Command{
+	Use:   "server",
+	Short: "Manage registered MCP servers",
+}
+
+var serverListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all registered MCP servers",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		summaries, err := listServerSummaries(path)
+		if err != nil {
+			return err
+		}
+		if serverListSelectorFlag != "" {
+			selector, err := parseSelector(serverListSelectorFlag)
+			if err != nil {
+				return err
+			}
+			var matched []serverSummary
+			for _, srv := range summaries {
+				if selector.matches(srv.Tags) {
+					matched = append(matched, srv)
+				}
+			}
+			summaries = matched
+		}
+		switch serverListOutputFlag {
+		case "csv":
+			return renderInventoryCSV(summaries, serverListWideFlag)
+		case "markdown":
+			renderInventoryMarkdown(summaries, serverListWideFlag)
+			return nil
+		case "table":
+		default:
+			return fmt.Errorf("unknown output format %q (want table, csv, or markdown)", serverListOutputFlag)
+		}
+		fmt.Printf("%s %s %s %6s %5s %8s  %-7s",
+			padTo("NAME", 24), padTo("CATEGORY", 14), padTo("STATUS", 18), "TOOLS", "RES", "PROMPTS", "ENABLED")
+		if serverListWideFlag {
+			fmt.Printf("  %s", "NOTE")
+		}
+		fmt.Println()
+		for _, srv := range summaries {
+			enabled := "no"
+			if srv.Enabled {
+				enabled = "yes"
+			}
+			// padTo measures display cells, so names with emoji or CJK
+			// characters don't shear the table.
+			fmt.Printf("%s %s %s %6d %5d %8d  %-7s",
+				padTo(srv.Name, 24), padTo(srv.Category, 14), padTo(srv.Status, 18),
+				srv.ToolCount, srv.ResourceCount, srv.PromptCount, enabled)
+			if serverListWideFlag {
+				fmt.Printf("  %s", latestNote(srv.Notes))
+			}
+			fmt.Println()
+		}
+		return nil
+	},
+}
+
+var serverStatusCmd = &cobra.Command{
+	Use:   "status <server>",
+	Short: "Show details for one server",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadRegistryPreferDaemon(path)
+		if err != nil {
+			return err
+		}
+		srv := reg.findServer(args[0])
+		if srv == nil {
+			return serverNotFoundError(args[0])
+		}
+		fmt.Printf("Name:        %s\n", srv.Name)
+		fmt.Printf("Endpoint:    %s\n", srv.Endpoint)
+		fmt.Printf("Framework:   %s\n", srv.Framework)
+		fmt.Printf("Status:      %s\n", srv.Status)
+		fmt.Printf("Version:     %s\n", srv.Version)
+		fmt.Printf("Category:    %s\n", srv.Category)
+		if srv.Owner != "" {
+			fmt.Printf("Owner:       %s\n", srv.Owner)
+		}
+		if srv.Contact != "" {
+			fmt.Printf("Contact:     %s\n", srv.Contact)
+		}
+		fmt.Printf("Enabled:     %v\n", srv.Enabled)
+		fmt.Printf("Tools:       %d\n", len(srv.Tools))
+		for _, tool := range srv.Tools {
+			fmt.Printf("  - %s\n", tool)
+		}
+		if len(srv.Resources) > 0 {
+			fmt.Printf("Resources:   %d\n", len(srv.Resources))
+			for _, res := range srv.Resources {
+				fmt.Printf("  - %s\n", res)
+			}
+		}
+		if len(srv.Prompts) > 0 {
+			fmt.Printf("Prompts:     %d\n", len(srv.Prompts))
+			for _, prompt := range srv.Prompts {
+				fmt.Printf("  - %s\n", prompt)
+			}
+		}
+		if len(srv.Roots) > 0 {
+			fmt.Printf("Roots:       %d\n", len(srv.Roots))
+			printRoots(srv, "  ")
+		}
+		if srv.Description != "" {
+			fmt.Printf("Description: %s\n", srv.Description)
+		}
+		if len(srv.Notes) > 0 {
+			fmt.Printf("Notes:       %d\n", len(srv.Notes))
+			for _, note := range srv.Notes {
+				fmt.Printf("  - %s (%s, %s)\n", note.Text, note.Author, note.At)
+			}
+		}
+		return nil
+	},
+}
+
+var serverToggleCmd = &cobra.Command{
+	Use:   "toggle <server>",
+	Short: "Enable or disable a server",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		var enabled bool
+		err = mutateServer(path, args[0], func(srv *MCPServer) error {
+			srv.Enabled = !srv.Enabled
+			enabled = srv.Enabled
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		state := "disabled"
+		if enabled {
+			state = "enabled"
+		}
+		fmt.Printf("Server %s is now %s\n", args[0], state)
+		return nil
+	},
+}
+
+var serverTagCmd = &cobra.Command{
+	Use:   "tag <server> <key=value | key->",
+	Short: "Set or remove tags on a server",
+	Long: `Sets key=value tags on a server; a trailing '-' (key-) removes a tag,
+matching kubectl label syntax. Tags feed the -l selector filters.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		return mutateServer(path, args[0], func(srv *MCPServer) error {
+			for _, arg := range args[1:] {
+				if key, ok := strings.CutSuffix(arg, "-"); ok && !strings.Contains(arg, "=") {
+					delete(srv.Tags, key)
+					continue
+				}
+				key, value, ok := strings.Cut(arg, "=")
+				if !ok || key == "" {
+					return fmt.Errorf("invalid tag %q (want key=value or key-)", arg)
+				}
+				if srv.Tags == nil {
+					srv.Tags = map[string]string{}
+				}
+				srv.Tags[key] = value
+			}
+			return nil
+		})
+	},
+}
+
+var serverExportToCmd = &cobra.Command{
+	Use:   "export-to <server> <client...|->",
+	Short: "Mark which clients a server is exported to",
+	Long: `Sets the server's export profile: the clients whose configs 'devgen
+export' may include it (claude, cursor, windsurf) or cli-only to keep it
+out of every client config. A single '-' clears the profile, restoring
+export to all clients.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		valid := map[string]bool{"cli-only": true}
+		for client := range clientTargets {
+			valid[client] = true
+		}
+		clients := args[1:]
+		if len(clients) == 1 && clients[0] == "-" {
+			clients = nil
+		}
+		for _, client := range clients {
+			if !valid[client] {
+				return fmt.Errorf("unknown client %q (want claude, cursor, windsurf, or cli-only)", client)
+			}
+		}
+		if err := mutateServer(path, args[0], func(srv *MCPServer) error {
+			srv.ExportTo = clients
+			return nil
+		}); err != nil {
+			return err
+		}
+		if clients == nil {
+			fmt.Printf("%s exports to all clients\n", args[0])
+		} else {
+			fmt.Printf("%s exports to: %s\n", args[0], strings.Join(clients, ", "))
+		}
+		return nil
+	},
+}
+
+var serverRemoveCmd = &cobra.Command{
+	Use:   "remove <server>",
+	Short: "Remove a server from the registry",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		if reg.findServer(args[0]) == nil {
+			return serverNotFoundError(args[0])
+		}
+		if err := confirmAction(fmt.Sprintf("remove %s from the registry", args[0])); err != nil {
+			return err
+		}
+		kept := reg.Servers[:0]
+		for _, srv := range reg.Servers {
+			if srv.Name != args[0] {
+				kept = append(kept, srv)
+			}
+		}
+		reg.Servers = kept
+		if err := saveMCPRegistry(path, reg); err != nil {
+			return err
+		}
+		fmt.Printf("Removed %s\n", args[0])
+		return nil
+	},
+}
+
+var (
+	serverListSelectorFlag string
+	serverListWideFlag     bool
+	serverListOutputFlag   string
+)
+
+func init() {
+	serverListCmd.Flags().StringVarP(&serverListSelectorFlag, "selector", "l", "",
+		"filter by label selector, e.g. env=prod,team=ml")
+	serverListCmd.Flags().BoolVar(&serverListWideFlag, "wide", false,
+		"include extra columns (latest note)")
+	serverListCmd.Flags().StringVarP(&serverListOutputFlag, "output", "o", "table",
+		"output format: table, csv, or markdown")
+	serverCmd.AddCommand(serverListCmd, serverStatusCmd, serverToggleCmd, serverTagCmd, serverExportToCmd, serverRemoveCmd)
+	rootCmd.AddCommand(serverCmd)
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Signing covers the fields an attacker would tamper with to run arbitrary
+// code: the server name, its launch spec, and its endpoint. Keys are
+// ed25519, generated on first use under the devgen config directory.
+// Trust is anchored in the trusted-keys file beside the config, never in
+// the registry record itself: a record that the attacker can rewrite
+// could otherwise just be re-signed with the attacker's key.
+
+const (
+	signingKeyFileName  = "signing.key"
+	trustedKeysFileName = "trusted_keys"
+)
+
+// signingConfig controls launch-spec signature enforcement. RequireSigned
+// refuses to launch any server that lacks a valid signature from a
+// trusted key, instead of treating signing as opt-in per record.
+type signingConfig struct {
+	RequireSigned bool `json:"require_signed,omitempty"`
+}
+
+// signedPayload is the canonical byte form covered by a signature.
+func signedPayload(srv *MCPServer) ([]byte, error) {
+	return json.Marshal(struct {
+		Name     string      `json:"name"`
+		Endpoint string      `json:"endpoint"`
+		Launch   *LaunchSpec `json:"launch,omitempty"`
+	}{Name: srv.Name, Endpoint: srv.Endpoint, Launch: srv.Launch})
+}
+
+// signingKeyPath returns the private key location beside the config file.
+func signingKeyPath() (string, error) {
+	path, err := configFilePath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), signingKeyFileName), nil
+}
+
+// loadOrCreateSigningKey reads the ed25519 seed, generating one on first
+// use.
+func loadOrCreateSigningKey() (ed25519.PrivateKey, error) {
+	path, err := signingKeyPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err == nil {
+		seed, err := base64.StdEncoding.DecodeString(string(data))
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return nil, fmt.Errorf("corrupt signing key %s", path)
+		}
+		return ed25519.NewKeyFromSeed(seed), nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	encoded := base64.StdEncoding.EncodeToString(key.Seed())
+	if err := os.WriteFile(path, []byte(encoded), 0o600); err != nil {
+		return nil, err
+	}
+	fmt.Printf("Generated signing key: %s\n", path)
+	return key, nil
+}
+
+// trustedKeysPath returns the trusted-keys file location beside the
+// config file: one base64 ed25519 public key per line, optionally
+// followed by a label, with blank lines and #-comments ignored. Keys for
+// other machines (fleet peers, a shared registry's signers) are added by
+// pasting their base64 key onto a new line.
+func trustedKeysPath() (string, error) {
+	path, err := configFilePath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), trustedKeysFileName), nil
+}
+
+// loadTrustedKeys reads the trusted-keys file; a missing file means no
+// keys are trusted.
+func loadTrustedKeys() ([]ed25519.PublicKey, error) {
+	path, err := trustedKeysPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var keys []ed25519.PublicKey
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		key, err := base64.StdEncoding.DecodeString(fields[0])
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("corrupt trusted key in %s: %q", path, fields[0])
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// trustKey appends a public key to the trusted-keys file unless it is
+// already listed.
+func trustKey(pub ed25519.PublicKey, label string) error {
+	existing, err := loadTrustedKeys()
+	if err != nil {
+		return err
+	}
+	for _, key := range existing {
+		if key.Equal(pub) {
+			return nil
+		}
+	}
+	path, err := trustedKeysPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = fmt.Fprintf(f, "%s %s\n", base64.StdEncoding.EncodeToString(pub), label)
+	return err
+}
+
+// verifyProvenance checks a server's signature against its current launch
+// fields and requires the signing key to appear in the trusted-keys file.
+// The public key stored in the registry record only identifies which key
+// signed; trust never comes from the record itself, so stripping the
+// provenance block or re-signing a tampered record with a fresh key does
+// not bypass verification. Unsigned servers pass only while
+// signing.require_signed is off.
+func verifyProvenance(srv *MCPServer) error {
+	if srv.Provenance == nil {
+		if cfg, err := loadConfig(); err == nil && cfg.Signing.RequireSigned {
+			return fmt.Errorf("%s: unsigned, and signing.require_signed is on", srv.Name)
+		}
+		return nil
+	}
+	pub, err := base64.StdEncoding.DecodeString(srv.Provenance.PublicKey)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return fmt.Errorf("%s: corrupt provenance public key", srv.Name)
+	}
+	trusted, err := loadTrustedKeys()
+	if err != nil {
+		return err
+	}
+	known := false
+	for _, key := range trusted {
+		if key.Equal(ed25519.PublicKey(pub)) {
+			known = true
+			break
+		}
+	}
+	if !known {
+		path, _ := trustedKeysPath()
+		return fmt.Errorf("%s: signed by a key not listed in %s", srv.Name, path)
+	}
+	sig, err := base64.StdEncoding.DecodeString(srv.Provenance.Signature)
+	if err != nil {
+		return fmt.Errorf("%s: corrupt provenance signature", srv.Name)
+	}
+	payload, err := signedPayload(srv)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(ed25519.PublicKey(pub), payload, sig) {
+		return fmt.Errorf("%s: launch command changed since it was signed by %s at %s",
+			srv.Name, srv.Provenance.SignedBy, srv.Provenance.SignedAt)
+	}
+	return nil
+}
+
+var signCmd = &cobra.Command{
+	Use:   "sign <server>",
+	Short: "Sign a server's launch command",
+	Long: `Signs the server's name, endpoint, and launch spec with this machine's
+devgen signing key and stores the signature in the registry. Commands that
+launch the server verify the signature against the trusted-keys file under
+the devgen config directory and refuse to run a record that changed since
+signing or was signed by an unlisted key. Signing adds this machine's key
+to that file; keys from other machines are added by appending their base64
+public key on a new line. Set signing.require_signed to refuse launching
+unsigned servers entirely.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		key, err := loadOrCreateSigningKey()
+		if err != nil {
+			return err
+		}
+		if err := trustKey(key.Public().(ed25519.PublicKey), currentActor()); err != nil {
+			return err
+		}
+		err = mutateServer(path, args[0], func(srv *MCPServer) error {
+			payload, err := signedPayload(srv)
+			if err != nil {
+				return err
+			}
+			srv.Provenance = &ServerProvenance{
+				SignedBy:  currentActor(),
+				SignedAt:  time.Now().UTC().Format(time.RFC3339),
+				PublicKey: base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey)),
+				Signature: base64.StdEncoding.EncodeToString(ed25519.Sign(key, payload)),
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Signed %s\n", args[0])
+		return nil
+	},
+}
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify [server]",
+	Short: "Verify registry entry signatures",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		requireSigned := false
+		if cfg, err := loadConfig(); err == nil {
+			requireSigned = cfg.Signing.RequireSigned
+		}
+		failures := 0
+		checked := 0
+		for i := range reg.Servers {
+			srv := &reg.Servers[i]
+			if len(args) == 1 && srv.Name != args[0] {
+				continue
+			}
+			checked++
+			if srv.Provenance == nil {
+				if requireSigned {
+					fmt.Printf("%-24s UNSIGNED (signing.require_signed is on)\n", srv.Name)
+					failures++
+				} else {
+					fmt.Printf("%-24s unsigned\n", srv.Name)
+				}
+				continue
+			}
+			if err := verifyProvenance(srv); err != nil {
+				fmt.Printf("%-24s TAMPERED: %v\n", srv.Name, err)
+				failures++
+				continue
+			}
+			fmt.Printf("%-24s ok (signed by %s at %s)\n", srv.Name, srv.Provenance.SignedBy, srv.Provenance.SignedAt)
+		}
+		if len(args) == 1 && checked == 0 {
+			return serverNotFoundError(args[0])
+		}
+		if failures > 0 {
+			return fmt.Errorf("%d signature(s) failed verification", failures)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(signCmd, verifyCmd)
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// healthHistoryFileName is an append-only JSON Lines log of damped health
+// transitions, stored next to the registry. SLA reports reconstruct uptime
+// from it.
+const healthHistoryFileName = "health_history.jsonl"
+
+// healthTransition is one damped up/down flip.
+type healthTransition struct {
+	Server string `json:"server"`
+	State  string `json:"state"` // "up" or "down"
+	At     string `json:"at"`
+}
+
+func healthHistoryPath() (string, error) {
+	path, err := registryPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), healthHistoryFileName), nil
+}
+
+// appendHealthTransition records one transition. Failures are non-fatal:
+// losing a history line must not break supervision.
+func appendHealthTransition(server, state string, at time.Time) {
+	path, err := healthHistoryPath()
+	if err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		debugf("sla: appending health transition: %v", err)
+		return
+	}
+	defer f.Close()
+	data, err := json.Marshal(healthTransition{Server: server, State: state, At: at.UTC().Format(time.RFC3339)})
+	if err != nil {
+		return
+	}
+	f.Write(append(data, '\n'))
+}
+
+// loadHealthTransitions reads the full history, oldest first.
+func loadHealthTransitions() ([]healthTransition, error) {
+	path, err := healthHistoryPath()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	var transitions []healthTransition
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var tr healthTransition
+		if err := json.Unmarshal(scanner.Bytes(), &tr); err != nil {
+			continue // a torn write must not poison the whole history
+		}
+		transitions = append(transitions, tr)
+	}
+	return transitions, scanner.Err()
+}
+
+// uptimeFraction reconstructs the fraction of the window a server spent up
+// from its transition history. The second return is false when the history
+// holds no information about the server at all.
+func uptimeFraction(transitions []healthTransition, server string, from, to time.Time) (float64, bool) {
+	var events []healthTransition
+	for _, tr := range transitions {
+		if tr.Server == server {
+			events = append(events, tr)
+		}
+	}
+	if len(events) == 0 {
+		return 0, false
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].At < events[j].At })
+
+	// State at the window start: the latest transition before it. A server
+	// whose first transition falls inside the window is assumed up before
+	// its first recorded flip.
+	state := "up"
+	cursor := from
+	var up time.Duration
+	for _, ev := range events {
+		at, err := time.Parse(time.RFC3339, ev.At)
+		if err != nil {
+			continue
+		}
+		if !at.After(from) {
+			state = ev.State
+			continue
+		}
+		if at.After(to) {
+			break
+		}
+		if state == "up" {
+			up += at.Sub(cursor)
+		}
+		cursor = at
+		state = ev.State
+	}
+	if state == "up" {
+		up += to.Sub(cursor)
+	}
+	return up.Seconds() / to.Sub(from).Seconds(), true
+}
+
+var slaCmd = &cobra.Command{
+	Use:   "sla",
+	Short: "Declare and report on availability targets",
+}
+
+var slaSetCmd = &cobra.Command{
+	Use:   "set <server> <target-percent>",
+	Short: "Set a server's expected availability target",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target, err := strconv.ParseFloat(args[1], 64)
+		if err != nil || target <= 0 || target > 100 {
+			return fmt.Errorf("target must be a percentage in (0, 100], got %q", args[1])
+		}
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		return mutateServer(path, args[0], func(srv *MCPServer) error {
+			srv.SLATarget = target
+			return nil
+		})
+	},
+}
+
+var slaWindowFlag time.Duration
+
+var slaReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Compare actual uptime against declared targets",
+	Long: `Reconstructs each server's uptime over the window from the damped
+health transition history and compares it to the declared sla_target.
+Servers without history report "no data" rather than a fabricated number.
+Exits non-zero when any server missed its target.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		transitions, err := loadHealthTransitions()
+		if err != nil {
+			return err
+		}
+		to := time.Now()
+		from := to.Add(-slaWindowFlag)
+
+		violations := 0
+		fmt.Printf("%-24s %8s %8s  %s\n", "NAME", "TARGET", "ACTUAL", "VERDICT")
+		for i := range reg.Servers {
+			srv := &reg.Servers[i]
+			if srv.SLATarget <= 0 {
+				continue
+			}
+			fraction, ok := uptimeFraction(transitions, srv.Name, from, to)
+			if !ok {
+				fmt.Printf("%-24s %7.2f%% %8s  no data in the last %s\n",
+					srv.Name, srv.SLATarget, "-", slaWindowFlag)
+				continue
+			}
+			actual := fraction * 100
+			verdict := "ok"
+			if actual < srv.SLATarget {
+				verdict = "MISSED"
+				if owner := ownerLine(srv); owner != "" {
+					verdict += " (" + owner + ")"
+				}
+				violations++
+			}
+			fmt.Printf("%-24s %7.2f%% %7.2f%%  %s\n", srv.Name, srv.SLATarget, actual, verdict)
+		}
+		if violations > 0 {
+			return fmt.Errorf("%d server(s) missed their availability target", violations)
+		}
+		return nil
+	},
+}
+
+func init() {
+	slaReportCmd.Flags().DurationVar(&slaWindowFlag, "window", 24*time.Hour, "reporting window, e.g. 24h or 168h")
+	slaCmd.AddCommand(slaSetCmd, slaReportCmd)
+	rootCmd.AddCommand(slaCmd)
+}
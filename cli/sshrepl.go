@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"golang.org/x/term"
+)
+
+// runSSHRepl serves a line-oriented command loop for operators who want a
+// prompt instead of the full-screen dashboard ("ssh -t host -- repl").
+// term.Terminal supplies line editing and up-arrow history; tab completes
+// subcommand and server names. Each line runs as a devgen subcommand, the
+// same way one-shot exec sessions do.
+func runSSHRepl(s ssh.Session) {
+	pty, winCh, hasPTY := s.Pty()
+	if !hasPTY {
+		wish.Fatalln(s, "repl needs a PTY; reconnect with ssh -t")
+		return
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		wish.Fatalln(s, err)
+		return
+	}
+
+	t := term.NewTerminal(s, "devgen> ")
+	t.SetSize(pty.Window.Width, pty.Window.Height)
+	go func() {
+		for win := range winCh {
+			t.SetSize(win.Width, win.Height)
+		}
+	}()
+	t.AutoCompleteCallback = replCompleter()
+
+	fmt.Fprintln(t, "devgen repl — tab completes commands and server names, 'exit' leaves")
+	for {
+		line, err := t.ReadLine()
+		if err != nil {
+			return // EOF (ctrl+d) or connection gone
+		}
+		line = strings.TrimSpace(line)
+		switch line {
+		case "":
+			continue
+		case "exit", "quit":
+			return
+		}
+		argv := strings.Fields(line)
+		cmd := exec.Command(exe, argv...)
+		// Writing through the terminal converts newlines for the raw PTY.
+		cmd.Stdout = t
+		cmd.Stderr = t
+		debugf("ssh repl: %v from %s", argv, s.RemoteAddr())
+		if err := cmd.Run(); err != nil {
+			if _, ok := err.(*exec.ExitError); !ok {
+				fmt.Fprintf(t, "error: %v\n", err)
+			}
+		}
+	}
+}
+
+// replCompleter builds the tab-completion callback: the first word
+// completes against devgen subcommand names, later words against server
+// names from the registry. Ambiguous input extends to the longest common
+// prefix; anything else is left untouched.
+func replCompleter() func(line string, pos int, key rune) (string, int, bool) {
+	var commands []string
+	for _, c := range rootCmd.Commands() {
+		if !c.Hidden {
+			commands = append(commands, c.Name())
+		}
+	}
+	sort.Strings(commands)
+
+	return func(line string, pos int, key rune) (string, int, bool) {
+		if key != '\t' || pos != len(line) {
+			return line, pos, false
+		}
+		start := strings.LastIndex(line, " ") + 1
+		token := line[start:]
+		candidates := commands
+		if start > 0 {
+			candidates = replServerNames()
+		}
+		completed := completeToken(token, candidates)
+		if completed == token {
+			return line, pos, false
+		}
+		newLine := line[:start] + completed
+		return newLine, len(newLine), true
+	}
+}
+
+// replServerNames lists registry server names for completion; a broken
+// registry just yields no candidates rather than breaking the prompt.
+func replServerNames() []string {
+	path, err := registryPath()
+	if err != nil {
+		return nil
+	}
+	reg, err := cachedRegistry(path)
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(reg.Servers))
+	for i := range reg.Servers {
+		names = append(names, reg.Servers[i].Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// completeToken returns the unique completion of token, the longest
+// common prefix of all matches, or token unchanged when nothing matches.
+func completeToken(token string, candidates []string) string {
+	var matches []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, token) {
+			matches = append(matches, c)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return token
+	case 1:
+		return matches[0] + " "
+	}
+	prefix := matches[0]
+	for _, m := range matches[1:] {
+		for !strings.HasPrefix(m, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
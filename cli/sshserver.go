@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"github.com/charmbracelet/wish/bubbletea"
+	"github.com/charmbracelet/wish/scp"
+	"github.com/spf13/cobra"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	sshAddrFlag           string
+	sshHostKeyFlag        string
+	sshMaxSessionsFlag    int
+	sshMaxPerIPFlag       int
+	sshIdleTimeoutFlag    time.Duration
+	sshMaxSessionTimeFlag time.Duration
+)
+
+// sshDuration resolves a timeout from its config string and flag override;
+// the flag wins when set.
+func sshDuration(name, configured string, flag time.Duration) (time.Duration, error) {
+	if flag > 0 {
+		return flag, nil
+	}
+	if configured == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(configured)
+	if err != nil {
+		return 0, fmt.Errorf("config ssh.%s: %w", name, err)
+	}
+	return d, nil
+}
+
+var sshCmd = &cobra.Command{
+	Use:   "ssh",
+	Short: "Serve the dashboard and CLI over SSH",
+	Long: `Runs an SSH server so remote operators can use devgen without a local
+install. Interactive sessions get the dashboard; sessions with a command
+run that devgen subcommand directly, so automation can do things like
+
+  ssh -p 2222 host -- server list
+  ssh -p 2222 host -- status --json
+
+'ssh -t host -- repl' opens a line-editing prompt with history and tab
+completion instead of the full-screen dashboard.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := registryPath(); err != nil {
+			return err
+		}
+		server, err := newSSHServer()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("devgen ssh listening on %s\n", sshAddrFlag)
+		return server.ListenAndServe()
+	},
+}
+
+func newSSHServer() (*ssh.Server, error) {
+	hostKey := sshHostKeyFlag
+	if hostKey == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		hostKey = filepath.Join(home, ".ssh", "devgen_host_ed25519")
+	}
+	root, err := findMachinaRoot()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	sshCfg := cfg.SSH
+	if sshMaxSessionsFlag > 0 {
+		sshCfg.MaxSessions = sshMaxSessionsFlag
+	}
+	if sshMaxPerIPFlag > 0 {
+		sshCfg.MaxPerIP = sshMaxPerIPFlag
+	}
+	// Download-only file handler rooted at the machina root, so clients can
+	// scp mcp_status.json, cached snapshots, and logs/<server>.log without a
+	// shell; the nil write handler rejects uploads.
+	files := scp.NewFileSystemHandler(root)
+	options := []ssh.Option{
+		wish.WithAddress(sshAddrFlag),
+		wish.WithHostKeyPath(hostKey),
+		// Middleware composes outermost-last: the session tracker wraps
+		// everything, then file transfers and exec requests are intercepted
+		// before the bubbletea dashboard takes over interactive sessions.
+		wish.WithMiddleware(
+			bubbletea.Middleware(sshDashboardHandler),
+			sshExecMiddleware,
+			scp.Middleware(files, nil),
+			sshSessionMiddleware(newSSHSessionTracker(), sshCfg),
+		),
+	}
+	if sshCfg.Banner != "" {
+		options = append(options, wish.WithBanner(sshCfg.Banner+"\n"))
+	}
+	idle, err := sshDuration("idle_timeout", sshCfg.IdleTimeout, sshIdleTimeoutFlag)
+	if err != nil {
+		return nil, err
+	}
+	if idle > 0 {
+		options = append(options, wish.WithIdleTimeout(idle))
+	}
+	maxTime, err := sshDuration("max_session_time", sshCfg.MaxSessionTime, sshMaxSessionTimeFlag)
+	if err != nil {
+		return nil, err
+	}
+	if maxTime > 0 {
+		options = append(options, wish.WithMaxTimeout(maxTime))
+	}
+	return wish.NewServer(options...)
+}
+
+// sshDashboardHandler builds a dashboard model for an interactive session.
+func sshDashboardHandler(s ssh.Session) (tea.Model, []tea.ProgramOption) {
+	path, err := registryPath()
+	if err != nil {
+		wish.Fatalln(s, err)
+		return nil, nil
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		wish.Fatalln(s, err)
+		return nil, nil
+	}
+	// One renderer per session, detecting the client terminal's color
+	// profile; a forced --color on the server overrides detection for
+	// terminals that advertise the wrong thing.
+	renderer := bubbletea.MakeRenderer(s)
+	if colorFlag != "" && colorFlag != "auto" {
+		if profile, err := parseColorProfile(colorFlag); err == nil {
+			renderer.SetColorProfile(profile)
+		}
+	}
+	model := dashboardModel{
+		registryPath: path,
+		collapsed:    map[string]bool{},
+		keys:         keymapFromConfig(cfg.Keymap),
+		help:         help.New(),
+		styles:       newDashboardStylesFor(resolveTheme(cfg.Theme), renderer),
+	}
+	return model, []tea.ProgramOption{tea.WithAltScreen()}
+}
+
+// sshExecMiddleware runs sessions that carry a command ("ssh host -- server
+// list") as devgen subcommands, relaying their output and exit code.
+func sshExecMiddleware(next ssh.Handler) ssh.Handler {
+	return func(s ssh.Session) {
+		argv := s.Command()
+		if len(argv) == 0 {
+			next(s)
+			return
+		}
+		if len(argv) == 1 && argv[0] == "repl" {
+			runSSHRepl(s)
+			return
+		}
+		exe, err := os.Executable()
+		if err != nil {
+			wish.Fatalln(s, err)
+			return
+		}
+		cmd := exec.Command(exe, argv...)
+		cmd.Stdin = s
+		cmd.Stdout = s
+		cmd.Stderr = s.Stderr()
+		debugf("ssh: exec %v from %s", argv, s.RemoteAddr())
+		if err := cmd.Run(); err != nil {
+			if exitErr, ok := err.(*exec.ExitError); ok {
+				s.Exit(exitErr.ExitCode())
+				return
+			}
+			wish.Fatalln(s, err)
+			return
+		}
+		s.Exit(0)
+	}
+}
+
+func init() {
+	sshCmd.Flags().StringVar(&sshAddrFlag, "addr", ":2222", "SSH listen address")
+	sshCmd.Flags().StringVar(&sshHostKeyFlag, "host-key", "", "host key path (generated when missing)")
+	sshCmd.Flags().IntVar(&sshMaxSessionsFlag, "max-sessions", 0, "maximum concurrent sessions (overrides config, 0 uses config)")
+	sshCmd.Flags().IntVar(&sshMaxPerIPFlag, "max-per-ip", 0, "maximum concurrent sessions per client IP (overrides config)")
+	sshCmd.Flags().DurationVar(&sshIdleTimeoutFlag, "idle-timeout", 0, "disconnect sessions idle for this long (overrides config)")
+	sshCmd.Flags().DurationVar(&sshMaxSessionTimeFlag, "max-session-time", 0, "disconnect sessions after this total duration (overrides config)")
+	rootCmd.AddCommand(sshCmd)
+}
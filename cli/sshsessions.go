@@ -0,0 +1,191 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"github.com/spf13/cobra"
+)
+
+// sshSessionsFileName is the live-session snapshot the SSH server keeps
+// beside the registry so 'devgen ssh sessions' can report connections from
+// another process.
+const sshSessionsFileName = "devgen_ssh_sessions.json"
+
+// sshSessionInfo describes one active SSH session.
+type sshSessionInfo struct {
+	User        string `json:"user"`
+	RemoteAddr  string `json:"remote_addr"`
+	ConnectedAt string `json:"connected_at"`
+	Command     string `json:"command,omitempty"`
+}
+
+// sshSessionSnapshot is the on-disk form, tagged with the server PID so
+// readers can tell a live snapshot from one left behind by a dead server.
+type sshSessionSnapshot struct {
+	PID      int              `json:"pid"`
+	Sessions []sshSessionInfo `json:"sessions"`
+}
+
+func sshSessionsPath() (string, error) {
+	path, err := registryPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), sshSessionsFileName), nil
+}
+
+// sshSessionTracker records active sessions and mirrors them to the
+// snapshot file on every change.
+type sshSessionTracker struct {
+	mu       sync.Mutex
+	nextID   int
+	sessions map[int]sshSessionInfo
+}
+
+func newSSHSessionTracker() *sshSessionTracker {
+	return &sshSessionTracker{sessions: map[int]sshSessionInfo{}}
+}
+
+func (t *sshSessionTracker) add(s ssh.Session) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.nextID++
+	t.sessions[t.nextID] = sshSessionInfo{
+		User:        s.User(),
+		RemoteAddr:  s.RemoteAddr().String(),
+		ConnectedAt: time.Now().UTC().Format(time.RFC3339),
+		Command:     strings.Join(s.Command(), " "),
+	}
+	t.writeSnapshotLocked()
+	return t.nextID
+}
+
+func (t *sshSessionTracker) remove(id int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.sessions, id)
+	t.writeSnapshotLocked()
+}
+
+func (t *sshSessionTracker) count() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.sessions)
+}
+
+// countForIP returns the number of active sessions from the given host.
+func (t *sshSessionTracker) countForIP(host string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n := 0
+	for _, info := range t.sessions {
+		if sessionHost(info.RemoteAddr) == host {
+			n++
+		}
+	}
+	return n
+}
+
+// sessionHost strips the port from a remote address.
+func sessionHost(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+func (t *sshSessionTracker) writeSnapshotLocked() {
+	path, err := sshSessionsPath()
+	if err != nil {
+		return
+	}
+	snapshot := sshSessionSnapshot{PID: os.Getpid()}
+	for _, info := range t.sessions {
+		snapshot.Sessions = append(snapshot.Sessions, info)
+	}
+	if data, err := json.Marshal(snapshot); err == nil {
+		os.WriteFile(path, append(data, '\n'), 0o644)
+	}
+}
+
+// sshSessionMiddleware enforces the session and per-IP limits, tracks
+// connection metrics, and prints the MOTD to interactive sessions.
+func sshSessionMiddleware(tracker *sshSessionTracker, cfg sshConfig) wish.Middleware {
+	return func(next ssh.Handler) ssh.Handler {
+		return func(s ssh.Session) {
+			if cfg.MaxSessions > 0 && tracker.count() >= cfg.MaxSessions {
+				wish.Fatalf(s, "devgen: session limit reached (%d active)", cfg.MaxSessions)
+				return
+			}
+			host := sessionHost(s.RemoteAddr().String())
+			if cfg.MaxPerIP > 0 && tracker.countForIP(host) >= cfg.MaxPerIP {
+				wish.Fatalf(s, "devgen: connection limit for %s reached (%d active)", host, cfg.MaxPerIP)
+				return
+			}
+			id := tracker.add(s)
+			defer tracker.remove(id)
+			debugf("ssh: session %d opened by %s from %s", id, s.User(), s.RemoteAddr())
+			if cfg.MOTD != "" && len(s.Command()) == 0 {
+				wish.Println(s, cfg.MOTD)
+			}
+			next(s)
+		}
+	}
+}
+
+var sshSessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "List active sessions on the devgen SSH server",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := sshSessionsPath()
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				fmt.Println("no SSH server has run here")
+				return nil
+			}
+			return err
+		}
+		var snapshot sshSessionSnapshot
+		if err := json.Unmarshal(data, &snapshot); err != nil {
+			return fmt.Errorf("corrupt session snapshot %s: %w", path, err)
+		}
+		if !processAlive(snapshot.PID) {
+			fmt.Printf("SSH server (pid %d) is not running\n", snapshot.PID)
+			return nil
+		}
+		if len(snapshot.Sessions) == 0 {
+			fmt.Println("no active sessions")
+			return nil
+		}
+		fmt.Printf("%s %s %10s  %s\n", padTo("USER", 12), padTo("REMOTE", 22), "DURATION", "COMMAND")
+		for _, info := range snapshot.Sessions {
+			duration := "?"
+			if connected, err := time.Parse(time.RFC3339, info.ConnectedAt); err == nil {
+				duration = time.Since(connected).Round(time.Second).String()
+			}
+			command := info.Command
+			if command == "" {
+				command = "(dashboard)"
+			}
+			fmt.Printf("%s %s %10s  %s\n", padTo(info.User, 12), padTo(info.RemoteAddr, 22), duration, command)
+		}
+		return nil
+	},
+}
+
+func init() {
+	sshCmd.AddCommand(sshSessionsCmd)
+}
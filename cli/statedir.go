@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Diagnostic output (debug log, captured traffic) goes under a state
+// directory instead of whatever directory the CLI happens to run in:
+// --debug-dir if set, else $XDG_STATE_HOME/devgen, else
+// ~/.local/state/devgen.
+
+// debugDirFlag overrides the state directory location.
+var debugDirFlag string
+
+// stateFileMaxBytes triggers rotation: a state file growing past this is
+// renamed to <name>.1 (replacing the previous rotation) before the next
+// write.
+const stateFileMaxBytes = 5 << 20
+
+// stateDir resolves and creates the devgen state directory.
+func stateDir() (string, error) {
+	dir := debugDirFlag
+	if dir == "" {
+		if xdg := os.Getenv("XDG_STATE_HOME"); xdg != "" {
+			dir = filepath.Join(xdg, "devgen")
+		} else {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", fmt.Errorf("resolving state directory: %w", err)
+			}
+			dir = filepath.Join(home, ".local", "state", "devgen")
+		}
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// stateFilePath returns the path of a named state file, rotating it first
+// when it has grown past stateFileMaxBytes.
+func stateFilePath(name string) (string, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, name)
+	if info, err := os.Stat(path); err == nil && info.Size() > stateFileMaxBytes {
+		os.Rename(path, path+".1")
+	}
+	return path, nil
+}
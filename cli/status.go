@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// statusSummary is the machine-readable output of devgen status.
+type statusSummary struct {
+	Servers  []serverStatus `json:"servers"`
+	Failures []string       `json:"failures,omitempty"`
+	Healthy  bool           `json:"healthy"`
+}
+
+type serverStatus struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Status  string `json:"status"`
+	Health  string `json:"health"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+var (
+	statusRequireFlag []string
+	statusJSONFlag    bool
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Probe all servers and report overall health",
+	Long: `Probes every registered server and prints a summary. With --require,
+exits non-zero if any named server is disabled or fails its probe, so CI
+jobs can gate on MCP availability.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadRegistryPreferDaemon(path)
+		if err != nil {
+			return err
+		}
+
+		probes := probeAll(reg.Servers)
+		summary := statusSummary{Healthy: true}
+		required := map[string]bool{}
+		for _, entry := range statusRequireFlag {
+			for _, name := range strings.Split(entry, ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					required[name] = true
+				}
+			}
+		}
+
+		for _, srv := range reg.Servers {
+			probe := probes[srv.Name]
+			summary.Servers = append(summary.Servers, serverStatus{
+				Name:    srv.Name,
+				Enabled: srv.Enabled,
+				Status:  srv.Status,
+				Health:  probe.State.String(),
+				Detail:  probe.Detail,
+			})
+			if required[srv.Name] {
+				delete(required, srv.Name)
+				if inMaintenance(&srv) {
+					// Maintenance suppresses health alerts: a required server
+					// being worked on should not fail the CI gate.
+					continue
+				}
+				if !srv.Enabled {
+					summary.Failures = append(summary.Failures, fmt.Sprintf("%s is disabled", srv.Name))
+				} else if probe.State != healthHealthy {
+					summary.Failures = append(summary.Failures,
+						fmt.Sprintf("%s is %s: %s", srv.Name, probe.State, probe.Detail))
+				}
+			}
+		}
+		for name := range required {
+			summary.Failures = append(summary.Failures, fmt.Sprintf("%s is not registered", name))
+		}
+		summary.Healthy = len(summary.Failures) == 0
+
+		if statusJSONFlag {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(summary); err != nil {
+				return err
+			}
+		} else {
+			fmt.Printf("%-24s %-8s %-12s %s\n", "NAME", "ENABLED", "HEALTH", "DETAIL")
+			for _, st := range summary.Servers {
+				enabled := "no"
+				if st.Enabled {
+					enabled = "yes"
+				}
+				fmt.Printf("%-24s %-8s %-12s %s\n", st.Name, enabled, st.Health, st.Detail)
+			}
+			for _, failure := range summary.Failures {
+				fmt.Printf("FAIL: %s\n", failure)
+			}
+		}
+
+		if !summary.Healthy {
+			return fmt.Errorf("%w: %d required server(s) failing", errHealthCheckFailed, len(summary.Failures))
+		}
+		return nil
+	},
+}
+
+func init() {
+	statusCmd.Flags().StringSliceVar(&statusRequireFlag, "require", nil, "comma-separated servers that must be enabled and healthy")
+	statusCmd.Flags().BoolVar(&statusJSONFlag, "json", false, "emit the summary as JSON")
+	rootCmd.AddCommand(statusCmd)
+}
@@ -0,0 +1,377 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// managedStateFileName tracks processes started by devgen, stored next to
+// the registry.
+const managedStateFileName = "managed_servers.json"
+
+// managedState is the on-disk record of supervised processes.
+type managedState struct {
+	Servers map[string]managedProcess `json:"servers"`
+}
+
+type managedProcess struct {
+	PID       int    `json:"pid"`
+	StartedAt string `json:"started_at"`
+	// Restarts records recent restart timestamps for crash loop detection.
+	Restarts []string `json:"restarts,omitempty"`
+}
+
+func managedStatePath() (string, error) {
+	path, err := registryPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), managedStateFileName), nil
+}
+
+func loadManagedState() (*managedState, error) {
+	path, err := managedStatePath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &managedState{Servers: map[string]managedProcess{}}, nil
+		}
+		return nil, err
+	}
+	var state managedState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if state.Servers == nil {
+		state.Servers = map[string]managedProcess{}
+	}
+	return &state, nil
+}
+
+func saveManagedState(state *managedState) error {
+	path, err := managedStatePath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dryRunFlag {
+		before, _ := os.ReadFile(path)
+		printDryRunDiff(path, string(before), string(data))
+		return nil
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// startManagedServer launches a server detached from the CLI, logging its
+// output under logs/ in the machina root.
+func startManagedServer(srv *MCPServer) (int, error) {
+	launch, err := resolveLaunchCommand(srv)
+	if err != nil {
+		return 0, err
+	}
+	root, err := findMachinaRoot()
+	if err != nil {
+		return 0, err
+	}
+	logDir := filepath.Join(root, "logs")
+	if err := os.MkdirAll(logDir, 0o755); err != nil {
+		return 0, err
+	}
+	logFile, err := os.OpenFile(filepath.Join(logDir, srv.Name+".log"),
+		os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return 0, err
+	}
+	defer logFile.Close()
+
+	command, cmdArgs, env, err := applySandbox(srv, launch.Command, launch.Args, launchEnviron(launch))
+	if err != nil {
+		return 0, err
+	}
+	cmd := exec.Command(command, cmdArgs...)
+	cmd.Dir = launch.Dir
+	if srv.Sandbox != nil && srv.Sandbox.Jail != "" {
+		info, err := os.Stat(srv.Sandbox.Jail)
+		if err != nil || !info.IsDir() {
+			return 0, fmt.Errorf("sandboxing %s: jail %s is not a directory", srv.Name, srv.Sandbox.Jail)
+		}
+		cmd.Dir = srv.Sandbox.Jail
+	}
+	cmd.Env = env
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	detachProcess(cmd)
+	if err := cmd.Start(); err != nil {
+		return 0, fmt.Errorf("starting %s: %w", srv.Name, err)
+	}
+	pid := cmd.Process.Pid
+	cmd.Process.Release()
+	fireServerStart(srv.Name, pid)
+	return pid, nil
+}
+
+var startCmd = &cobra.Command{
+	Use:   "start <server>",
+	Short: "Start a server as a managed background process",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		srv := reg.findServer(args[0])
+		if srv == nil {
+			return serverNotFoundError(args[0])
+		}
+		state, err := loadManagedState()
+		if err != nil {
+			return err
+		}
+		if existing, ok := state.Servers[srv.Name]; ok && processAlive(existing.PID) {
+			return fmt.Errorf("%s is already running (pid %d)", srv.Name, existing.PID)
+		}
+		if dryRunFlag {
+			launch, err := resolveLaunchCommand(srv)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("dry-run: would start %s: %s %s\n", srv.Name, launch.Command, strings.Join(launch.Args, " "))
+			return nil
+		}
+		if srv.Status == statusQuarantined {
+			if err := releaseQuarantine(srv); err != nil {
+				return err
+			}
+		}
+		pid, err := startManagedServer(srv)
+		if err != nil {
+			return err
+		}
+		state.Servers[srv.Name] = managedProcess{
+			PID:       pid,
+			StartedAt: time.Now().UTC().Format(time.RFC3339),
+		}
+		if err := saveManagedState(state); err != nil {
+			return err
+		}
+		fmt.Printf("Started %s (pid %d)\n", srv.Name, pid)
+		return nil
+	},
+}
+
+var stopAllFlag bool
+
+var stopCmd = &cobra.Command{
+	Use:   "stop <server>",
+	Short: "Stop a managed server process",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		state, err := loadManagedState()
+		if err != nil {
+			return err
+		}
+		if stopAllFlag {
+			return stopAllManaged(state)
+		}
+		if len(args) != 1 {
+			return fmt.Errorf("name a server or pass --all")
+		}
+		proc, ok := state.Servers[args[0]]
+		if !ok || !processAlive(proc.PID) {
+			return fmt.Errorf("%s is not running under devgen", args[0])
+		}
+		if dryRunFlag {
+			fmt.Printf("dry-run: would stop %s (pid %d)\n", args[0], proc.PID)
+			return nil
+		}
+		if err := terminateProcess(proc.PID); err != nil {
+			return fmt.Errorf("stopping %s (pid %d): %w", args[0], proc.PID, err)
+		}
+		delete(state.Servers, args[0])
+		if err := saveManagedState(state); err != nil {
+			return err
+		}
+		fmt.Printf("Stopped %s (pid %d)\n", args[0], proc.PID)
+		return nil
+	},
+}
+
+// stopAllManaged stops every running managed server after confirmation.
+func stopAllManaged(state *managedState) error {
+	running := 0
+	for _, proc := range state.Servers {
+		if processAlive(proc.PID) {
+			running++
+		}
+	}
+	if running == 0 {
+		fmt.Println("No managed servers running.")
+		return nil
+	}
+	if err := confirmAction(fmt.Sprintf("stop all %d managed server(s)", running)); err != nil {
+		return err
+	}
+	for name, proc := range state.Servers {
+		if !processAlive(proc.PID) {
+			continue
+		}
+		if dryRunFlag {
+			fmt.Printf("dry-run: would stop %s (pid %d)\n", name, proc.PID)
+			continue
+		}
+		if err := terminateProcess(proc.PID); err != nil {
+			fmt.Printf("Stopping %s (pid %d) failed: %v\n", name, proc.PID, err)
+			continue
+		}
+		delete(state.Servers, name)
+		fmt.Printf("Stopped %s (pid %d)\n", name, proc.PID)
+	}
+	return saveManagedState(state)
+}
+
+var psEnforceFlag bool
+
+var psCmd = &cobra.Command{
+	Use:   "ps",
+	Short: "Show managed server processes with resource usage",
+	Long: `Lists every devgen-managed server process with its CPU, RSS memory,
+and open file descriptor counts. With --enforce, servers exceeding their
+declared resource limits are restarted.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		state, err := loadManagedState()
+		if err != nil {
+			return err
+		}
+		if len(state.Servers) == 0 {
+			fmt.Println("No managed servers. Start one with 'devgen start <server>'.")
+			return nil
+		}
+
+		fmt.Printf("%-24s %8s %8s %8s %8s %6s  %s\n", "NAME", "PID", "UPTIME", "CPU%", "RSS", "FDS", "STATE")
+		for name, proc := range state.Servers {
+			if !processAlive(proc.PID) {
+				stateCol := "exited"
+				srv := reg.findServer(name)
+				if srv != nil && shouldAutoRestart(srv) {
+					if err := validateRestartPolicy(srv); err != nil {
+						stateCol = err.Error()
+					} else if !backoffElapsed(srv, proc) {
+						stateCol = fmt.Sprintf("exited (restart in %s)",
+							restartBackoff(srv, len(proc.Restarts)).Round(time.Second))
+					} else if err := restartManagedServer(srv, state); err != nil {
+						stateCol = fmt.Sprintf("restart failed: %v", err)
+					} else {
+						stateCol = "restarted"
+					}
+				}
+				fmt.Printf("%-24s %8d %8s %8s %8s %6s  %s\n", name, proc.PID, "-", "-", "-", "-", stateCol)
+				continue
+			}
+			sample, err := sampleProcess(proc.PID)
+			if err != nil {
+				fmt.Printf("%-24s %8d %8s %8s %8s %6s  %s\n", name, proc.PID, "-", "-", "-", "-", err)
+				continue
+			}
+			uptime := "-"
+			if started, err := time.Parse(time.RFC3339, proc.StartedAt); err == nil {
+				uptime = healthAge(started)
+			}
+			stateCol := "running"
+			srv := reg.findServer(name)
+			if srv != nil && overResourceLimits(srv, sample) {
+				stateCol = "over limit"
+				if psEnforceFlag {
+					if err := restartManagedServer(srv, state); err != nil {
+						stateCol = fmt.Sprintf("restart failed: %v", err)
+					} else {
+						stateCol = "restarted"
+					}
+				}
+			}
+			fmt.Printf("%-24s %8d %8s %7.1f%% %7.1fM %6d  %s\n",
+				name, proc.PID, uptime, sample.CPUPercent, sample.RSSMegabytes, sample.OpenFDs, stateCol)
+		}
+		return saveManagedState(state)
+	},
+}
+
+// restartManagedServer stops and relaunches one server, updating state. A
+// server restarting too often is quarantined instead of relaunched.
+func restartManagedServer(srv *MCPServer, state *managedState) error {
+	proc := state.Servers[srv.Name]
+	if dryRunFlag {
+		fmt.Printf("dry-run: would restart %s (pid %d)\n", srv.Name, proc.PID)
+		return nil
+	}
+	if recordRestart(&proc) > crashLoopThreshold {
+		state.Servers[srv.Name] = proc
+		if err := quarantineServer(srv); err != nil {
+			return err
+		}
+		return fmt.Errorf("%s is crash looping and has been quarantined", srv.Name)
+	}
+	if processAlive(proc.PID) {
+		terminateProcess(proc.PID)
+		time.Sleep(500 * time.Millisecond)
+		if processAlive(proc.PID) {
+			killProcess(proc.PID)
+		}
+	}
+	pid, err := startManagedServer(srv)
+	if err != nil {
+		return err
+	}
+	proc.PID = pid
+	proc.StartedAt = time.Now().UTC().Format(time.RFC3339)
+	state.Servers[srv.Name] = proc
+	return nil
+}
+
+// overResourceLimits checks a sample against the server's declared limits.
+func overResourceLimits(srv *MCPServer, sample procSample) bool {
+	limits := srv.Limits
+	if limits == nil {
+		return false
+	}
+	if limits.MaxRSSMegabytes > 0 && sample.RSSMegabytes > float64(limits.MaxRSSMegabytes) {
+		return true
+	}
+	if limits.MaxCPUPercent > 0 && sample.CPUPercent > float64(limits.MaxCPUPercent) {
+		return true
+	}
+	if limits.MaxOpenFDs > 0 && sample.OpenFDs > limits.MaxOpenFDs {
+		return true
+	}
+	return false
+}
+
+func init() {
+	psCmd.Flags().BoolVar(&psEnforceFlag, "enforce", false, "restart servers exceeding their resource limits")
+	stopCmd.Flags().BoolVar(&stopAllFlag, "all", false, "stop every managed server")
+	rootCmd.AddCommand(startCmd, stopCmd, psCmd)
+}
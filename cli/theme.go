@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// theme holds the dashboard color roles. Values are hex colors or ANSI
+// palette indexes, as accepted by lipgloss.Color.
+type theme struct {
+	Title    string `json:"title"`
+	Header   string `json:"header"`
+	Enabled  string `json:"enabled"`
+	Disabled string `json:"disabled"`
+	Footer   string `json:"footer"`
+	Selected string `json:"selected"`
+	Border   string `json:"border"`
+}
+
+// builtinThemes are the selectable palettes. "neon" matches the Machina
+// branding; "light" suits light terminal backgrounds; "colorblind" uses an
+// Okabe-Ito-derived palette distinguishable under common color blindness.
+var builtinThemes = map[string]theme{
+	"neon": {
+		Title:    "#00ffff",
+		Header:   "#ff0080",
+		Enabled:  "#00ff00",
+		Disabled: "#666666",
+		Footer:   "#ffff00",
+		Selected: "#00ffff",
+		Border:   "#00ffff",
+	},
+	"light": {
+		Title:    "#005f87",
+		Header:   "#87005f",
+		Enabled:  "#008700",
+		Disabled: "#8a8a8a",
+		Footer:   "#5f5f00",
+		Selected: "#005fd7",
+		Border:   "#005f87",
+	},
+	"colorblind": {
+		Title:    "#0072b2",
+		Header:   "#cc79a7",
+		Enabled:  "#009e73",
+		Disabled: "#999999",
+		Footer:   "#e69f00",
+		Selected: "#56b4e9",
+		Border:   "#0072b2",
+	},
+}
+
+// themeConfig selects a theme by name with optional per-role color
+// overrides from the config file.
+type themeConfig struct {
+	Name   string            `json:"name,omitempty"`
+	Colors map[string]string `json:"colors,omitempty"`
+}
+
+// resolveTheme builds the active theme from config: named preset first,
+// then per-role color overrides.
+func resolveTheme(cfg themeConfig) theme {
+	name := cfg.Name
+	if name == "" {
+		name = "neon"
+	}
+	active, ok := builtinThemes[name]
+	if !ok {
+		active = builtinThemes["neon"]
+	}
+	if v, ok := cfg.Colors["title"]; ok {
+		active.Title = v
+	}
+	if v, ok := cfg.Colors["header"]; ok {
+		active.Header = v
+	}
+	if v, ok := cfg.Colors["enabled"]; ok {
+		active.Enabled = v
+	}
+	if v, ok := cfg.Colors["disabled"]; ok {
+		active.Disabled = v
+	}
+	if v, ok := cfg.Colors["footer"]; ok {
+		active.Footer = v
+	}
+	if v, ok := cfg.Colors["selected"]; ok {
+		active.Selected = v
+	}
+	if v, ok := cfg.Colors["border"]; ok {
+		active.Border = v
+	}
+	return active
+}
+
+// dashboardStyles are the lipgloss styles derived from the active theme.
+type dashboardStyles struct {
+	title    lipgloss.Style
+	header   lipgloss.Style
+	enabled  lipgloss.Style
+	disabled lipgloss.Style
+	footer   lipgloss.Style
+	selected lipgloss.Style
+	card     lipgloss.Style
+}
+
+func newDashboardStyles(t theme) dashboardStyles {
+	return newDashboardStylesFor(t, lipgloss.DefaultRenderer())
+}
+
+// newDashboardStylesFor derives the styles through a specific renderer.
+// Remote sessions pass the renderer wish built for the client terminal so
+// profile detection (truecolor/256/ascii) follows the session, not the
+// server's own terminal; styles are built once per session, not per frame
+// or per command.
+func newDashboardStylesFor(t theme, r *lipgloss.Renderer) dashboardStyles {
+	return dashboardStyles{
+		title:    r.NewStyle().Foreground(lipgloss.Color(t.Title)).Bold(true),
+		header:   r.NewStyle().Foreground(lipgloss.Color(t.Header)).Bold(true),
+		enabled:  r.NewStyle().Foreground(lipgloss.Color(t.Enabled)),
+		disabled: r.NewStyle().Foreground(lipgloss.Color(t.Disabled)),
+		footer:   r.NewStyle().Foreground(lipgloss.Color(t.Footer)),
+		selected: r.NewStyle().Foreground(lipgloss.Color(t.Selected)).Bold(true),
+		card: r.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color(t.Border)),
+	}
+}
+
+// parseColorProfile maps a --color value to a termenv profile. "auto"
+// and "" keep detection.
+func parseColorProfile(name string) (termenv.Profile, error) {
+	switch name {
+	case "truecolor":
+		return termenv.TrueColor, nil
+	case "256":
+		return termenv.ANSI256, nil
+	case "16":
+		return termenv.ANSI, nil
+	case "ascii", "none":
+		return termenv.Ascii, nil
+	}
+	return termenv.Ascii, fmt.Errorf("--color %q: want auto, truecolor, 256, 16, or ascii", name)
+}
+
+// applyColorPreference applies --no-color (and the NO_COLOR convention,
+// https://no-color.org) or a forced --color profile. --no-color wins.
+func applyColorPreference(noColorFlag bool, colorName string) error {
+	if noColorFlag || os.Getenv("NO_COLOR") != "" {
+		lipgloss.SetColorProfile(termenv.Ascii)
+		return nil
+	}
+	if colorName == "" || colorName == "auto" {
+		return nil
+	}
+	profile, err := parseColorProfile(colorName)
+	if err != nil {
+		return err
+	}
+	lipgloss.SetColorProfile(profile)
+	return nil
+}
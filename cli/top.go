@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+// topRefreshInterval is how often devgen top resamples processes and traffic.
+const topRefreshInterval = 3 * time.Second
+
+// trafficWindow is the sliding window used for request and error rates.
+const trafficWindow = time.Minute
+
+// topRow is one server's live stats in the top view.
+type topRow struct {
+	name       string
+	pid        int
+	alive      bool
+	sample     procSample
+	requests   int
+	errors     int
+	overLimits bool
+}
+
+// topModel is the bubbletea model for devgen top.
+type topModel struct {
+	registryPath string
+	rows         []topRow
+	width        int
+	styles       dashboardStyles
+	err          error
+}
+
+// topTickMsg triggers a refresh cycle.
+type topTickMsg struct{}
+
+// topRowsMsg delivers a completed stats sweep.
+type topRowsMsg []topRow
+
+var topCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Live resource and request view for managed servers",
+	Long: `Shows per-server CPU, memory, and file descriptor usage for managed
+processes alongside request and error rates from captured proxy traffic,
+refreshing every few seconds.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		model := topModel{
+			registryPath: path,
+			styles:       newDashboardStyles(resolveTheme(cfg.Theme)),
+		}
+		_, err = tea.NewProgram(model, tea.WithAltScreen()).Run()
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(topCmd)
+}
+
+func (m topModel) Init() tea.Cmd {
+	return collectTopRowsCmd(m.registryPath)
+}
+
+func (m topModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			return m, tea.Quit
+		}
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+	case topTickMsg:
+		return m, collectTopRowsCmd(m.registryPath)
+	case topRowsMsg:
+		m.rows = msg
+		m.err = nil
+		return m, nextTopTick()
+	case error:
+		m.err = msg
+		return m, nextTopTick()
+	}
+	return m, nil
+}
+
+func nextTopTick() tea.Cmd {
+	return tea.Tick(topRefreshInterval, func(time.Time) tea.Msg { return topTickMsg{} })
+}
+
+// collectTopRowsCmd gathers process samples and traffic rates off the UI
+// goroutine.
+func collectTopRowsCmd(path string) tea.Cmd {
+	return func() tea.Msg {
+		reg, err := loadRegistryPreferDaemon(path)
+		if err != nil {
+			return err
+		}
+		state, err := loadManagedState()
+		if err != nil {
+			return err
+		}
+		requests, errors := trafficRates(trafficWindow)
+
+		var rows []topRow
+		for name, proc := range state.Servers {
+			row := topRow{
+				name:     name,
+				pid:      proc.PID,
+				alive:    processAlive(proc.PID),
+				requests: requests[name],
+				errors:   errors[name],
+			}
+			if row.alive {
+				if sample, err := sampleProcess(proc.PID); err == nil {
+					row.sample = sample
+					if srv := reg.findServer(name); srv != nil {
+						row.overLimits = overResourceLimits(srv, sample)
+					}
+				}
+			}
+			rows = append(rows, row)
+		}
+		// Include servers that only appear in traffic (e.g. proxied but not
+		// managed) so their request rates are still visible.
+		for name := range requests {
+			if _, ok := state.Servers[name]; !ok {
+				rows = append(rows, topRow{name: name, requests: requests[name], errors: errors[name]})
+			}
+		}
+		sort.Slice(rows, func(i, j int) bool { return rows[i].name < rows[j].name })
+		return topRowsMsg(rows)
+	}
+}
+
+// trafficRates counts requests and errors per server within the window.
+func trafficRates(window time.Duration) (requests, errors map[string]int) {
+	requests = map[string]int{}
+	errors = map[string]int{}
+	records, err := readTrafficLog()
+	if err != nil {
+		return requests, errors
+	}
+	cutoff := time.Now().Add(-window)
+	for _, rec := range records {
+		ts, err := time.Parse(time.RFC3339, rec.Timestamp)
+		if err != nil || ts.Before(cutoff) {
+			continue
+		}
+		requests[rec.Server]++
+		if rec.Error != "" {
+			errors[rec.Server]++
+		}
+	}
+	return requests, errors
+}
+
+func (m topModel) View() string {
+	out := m.styles.title.Render("devgen top") + "  " +
+		m.styles.footer.Render(fmt.Sprintf("refresh %s | traffic window %s | q to quit",
+			topRefreshInterval, trafficWindow)) + "\n\n"
+	if m.err != nil {
+		return out + m.styles.header.Render(fmt.Sprintf("error: %v", m.err)) + "\n"
+	}
+	if len(m.rows) == 0 {
+		return out + "No managed servers or recent traffic.\n" +
+			m.styles.footer.Render("Start servers with 'devgen start' or capture traffic with 'devgen proxy'.") + "\n"
+	}
+
+	out += m.styles.header.Render(fmt.Sprintf("%-24s %8s %7s %8s %6s %8s %7s  %s",
+		"NAME", "PID", "CPU%", "RSS", "FDS", "REQ/MIN", "ERR%", "STATE")) + "\n"
+	for _, row := range m.rows {
+		state := "running"
+		cpu, rss, fds := "-", "-", "-"
+		pid := "-"
+		switch {
+		case row.pid == 0:
+			state = "traffic only"
+		case !row.alive:
+			state = "exited"
+			pid = fmt.Sprintf("%d", row.pid)
+		default:
+			pid = fmt.Sprintf("%d", row.pid)
+			cpu = fmt.Sprintf("%.1f", row.sample.CPUPercent)
+			rss = fmt.Sprintf("%.1fM", row.sample.RSSMegabytes)
+			fds = fmt.Sprintf("%d", row.sample.OpenFDs)
+		}
+		errRate := "-"
+		if row.requests > 0 {
+			errRate = fmt.Sprintf("%.1f", float64(row.errors)/float64(row.requests)*100)
+		}
+		line := fmt.Sprintf("%-24s %8s %7s %8s %6s %8d %7s  %s",
+			row.name, pid, cpu, rss, fds, row.requests, errRate, state)
+		if row.overLimits {
+			line = m.styles.header.Render(line + "  over limit")
+		}
+		out += line + "\n"
+	}
+	return out
+}
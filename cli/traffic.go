@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+// trafficLogFile is the JSONL log the proxy appends request/response pairs
+// to, stored in the state directory.
+const trafficLogFile = "traffic.jsonl"
+
+// trafficRecord is one sanitized JSON-RPC request/response pair captured by
+// the proxy. Request and Response hold the sanitized payloads; sizes are the
+// original wire sizes in bytes.
+type trafficRecord struct {
+	Timestamp     string          `json:"timestamp"`
+	Server        string          `json:"server"`
+	Method        string          `json:"method"`
+	ID            json.RawMessage `json:"id,omitempty"`
+	DurationMs    float64         `json:"duration_ms"`
+	RequestBytes  int             `json:"request_bytes"`
+	ResponseBytes int             `json:"response_bytes"`
+	Error         string          `json:"error,omitempty"`
+	Request       json.RawMessage `json:"request,omitempty"`
+	Response      json.RawMessage `json:"response,omitempty"`
+}
+
+// sensitiveKeys are redacted from captured payloads before logging.
+var sensitiveKeys = []string{
+	"token", "password", "secret", "authorization", "api_key", "apikey", "credential",
+}
+
+// sanitizePayload redacts values whose keys look sensitive, recursively.
+func sanitizePayload(raw json.RawMessage) json.RawMessage {
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil
+	}
+	sanitized := sanitizeValue(value)
+	out, err := json.Marshal(sanitized)
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+func sanitizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, inner := range v {
+			if isSensitiveKey(key) {
+				v[key] = "[REDACTED]"
+				continue
+			}
+			v[key] = sanitizeValue(inner)
+		}
+		return v
+	case []interface{}:
+		for i, inner := range v {
+			v[i] = sanitizeValue(inner)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(lower, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// trafficLogger appends traffic records to the traffic log file.
+type trafficLogger struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newTrafficLogger() (*trafficLogger, error) {
+	path, err := stateFilePath(trafficLogFile)
+	if err != nil {
+		return nil, fmt.Errorf("opening traffic log: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("opening traffic log: %w", err)
+	}
+	return &trafficLogger{file: f}, nil
+}
+
+func (t *trafficLogger) record(rec trafficRecord) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return
+	}
+	t.file.Write(append(data, '\n'))
+}
+
+func (t *trafficLogger) Close() error {
+	return t.file.Close()
+}
+
+// readTrafficLog loads records from the traffic log, newest last.
+func readTrafficLog() ([]trafficRecord, error) {
+	path, err := stateFilePath(trafficLogFile)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []trafficRecord
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var rec trafficRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			continue // skip corrupt lines rather than failing the viewer
+		}
+		records = append(records, rec)
+	}
+	return records, scanner.Err()
+}
+
+var (
+	trafficServerFlag string
+	trafficMethodFlag string
+	trafficLimitFlag  int
+	trafficJSONFlag   bool
+)
+
+var trafficCmd = &cobra.Command{
+	Use:   "traffic",
+	Short: "View captured proxy request/response traffic",
+	Long: `Shows JSON-RPC request/response pairs captured while running
+'devgen proxy', with method, duration, and payload sizes. Payloads are
+sanitized before capture; credentials never reach the log.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		records, err := readTrafficLog()
+		if err != nil {
+			return err
+		}
+
+		var filtered []trafficRecord
+		for _, rec := range records {
+			if trafficServerFlag != "" && rec.Server != trafficServerFlag {
+				continue
+			}
+			if trafficMethodFlag != "" && rec.Method != trafficMethodFlag {
+				continue
+			}
+			filtered = append(filtered, rec)
+		}
+		if trafficLimitFlag > 0 && len(filtered) > trafficLimitFlag {
+			filtered = filtered[len(filtered)-trafficLimitFlag:]
+		}
+
+		if trafficJSONFlag {
+			enc := json.NewEncoder(os.Stdout)
+			for _, rec := range filtered {
+				if err := enc.Encode(rec); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		if len(filtered) == 0 {
+			fmt.Println("No traffic captured. Run 'devgen proxy <server>' first.")
+			return nil
+		}
+		fmt.Printf("%-24s %-20s %-28s %10s %8s %8s\n",
+			"TIMESTAMP", "SERVER", "METHOD", "DURATION", "REQ", "RESP")
+		for _, rec := range filtered {
+			status := fmt.Sprintf("%.1fms", rec.DurationMs)
+			if rec.Error != "" {
+				status = "error"
+			}
+			fmt.Printf("%-24s %-20s %-28s %10s %7dB %7dB\n",
+				rec.Timestamp, rec.Server, rec.Method, status,
+				rec.RequestBytes, rec.ResponseBytes)
+		}
+		return nil
+	},
+}
+
+func init() {
+	trafficCmd.Flags().StringVar(&trafficServerFlag, "server", "", "filter by server name")
+	trafficCmd.Flags().StringVar(&trafficMethodFlag, "method", "", "filter by JSON-RPC method")
+	trafficCmd.Flags().IntVar(&trafficLimitFlag, "limit", 20, "show at most N records (0 = all)")
+	trafficCmd.Flags().BoolVar(&trafficJSONFlag, "json", false, "emit records as JSON lines")
+
+	rootCmd.AddCommand(trafficCmd)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// registryWarnings inspects the registry for configuration conflicts:
+// duplicate server names, tool names exposed by more than one server, and
+// HTTP servers claiming the same port.
+func registryWarnings(reg *MCPRegistry) []string {
+	var warnings []string
+
+	names := map[string]int{}
+	for _, srv := range reg.Servers {
+		names[srv.Name]++
+	}
+	for _, name := range sortedKeys(names) {
+		if names[name] > 1 {
+			warnings = append(warnings, fmt.Sprintf(
+				"duplicate server name %q (%d entries)", name, names[name]))
+		}
+	}
+
+	toolOwners := map[string][]string{}
+	for _, srv := range reg.Servers {
+		for _, tool := range srv.Tools {
+			toolOwners[tool] = append(toolOwners[tool], srv.Name)
+		}
+	}
+	for _, tool := range sortedKeys(toolOwners) {
+		if owners := toolOwners[tool]; len(owners) > 1 {
+			warnings = append(warnings, fmt.Sprintf(
+				"tool %q exposed by multiple servers: %s", tool, strings.Join(owners, ", ")))
+		}
+	}
+
+	portOwners := map[string][]string{}
+	for _, srv := range reg.Servers {
+		if !strings.HasPrefix(srv.Endpoint, "http://") && !strings.HasPrefix(srv.Endpoint, "https://") {
+			continue
+		}
+		parsed, err := url.Parse(srv.Endpoint)
+		if err != nil || parsed.Port() == "" {
+			continue
+		}
+		key := parsed.Hostname() + ":" + parsed.Port()
+		portOwners[key] = append(portOwners[key], srv.Name)
+	}
+	for _, hostPort := range sortedKeys(portOwners) {
+		if owners := portOwners[hostPort]; len(owners) > 1 {
+			warnings = append(warnings, fmt.Sprintf(
+				"port conflict on %s: %s", hostPort, strings.Join(owners, ", ")))
+		}
+	}
+	return warnings
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the registry for duplicates and conflicts",
+	Long: `Flags duplicate server names, tool names exposed by more than one
+server, and port conflicts between HTTP servers. Exits non-zero when any
+conflict is found so CI can gate registry changes.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := registryPath()
+		if err != nil {
+			return err
+		}
+		reg, err := loadMCPRegistry(path)
+		if err != nil {
+			return err
+		}
+		warnings := registryWarnings(reg)
+		if len(warnings) == 0 {
+			fmt.Printf("Registry OK: %d server(s), no conflicts.\n", len(reg.Servers))
+			return nil
+		}
+		for _, warning := range warnings {
+			fmt.Printf("WARNING: %s\n", warning)
+		}
+		return fmt.Errorf("%d conflict(s) found", len(warnings))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
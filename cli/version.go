@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/spf13/cobra"
+)
+
+// Build metadata injected via ldflags, e.g.
+//
+//	go build -ldflags "-X main.buildCommit=$(git rev-parse --short HEAD) \
+//	                   -X main.buildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// The defaults mark a build made without the Makefile.
+var (
+	buildCommit = "unknown"
+	buildDate   = "unknown"
+)
+
+// versionInfo is the machine-readable version document.
+type versionInfo struct {
+	Version          string   `json:"version"`
+	Commit           string   `json:"commit"`
+	BuildDate        string   `json:"build_date"`
+	GoVersion        string   `json:"go_version"`
+	Platform         string   `json:"platform"`
+	ProtocolVersions []string `json:"mcp_protocol_versions"`
+}
+
+func currentVersionInfo() versionInfo {
+	return versionInfo{
+		Version:          devgenVersion,
+		Commit:           buildCommit,
+		BuildDate:        buildDate,
+		GoVersion:        runtime.Version(),
+		Platform:         runtime.GOOS + "/" + runtime.GOARCH,
+		ProtocolVersions: supportedProtocolVersions,
+	}
+}
+
+var versionJSONFlag bool
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Show version and build metadata",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info := currentVersionInfo()
+		if versionJSONFlag {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(info)
+		}
+		fmt.Printf("devgen %s\n", info.Version)
+		fmt.Printf("  commit:        %s\n", info.Commit)
+		fmt.Printf("  built:         %s\n", info.BuildDate)
+		fmt.Printf("  go:            %s (%s)\n", info.GoVersion, info.Platform)
+		fmt.Printf("  mcp protocol:  %v\n", info.ProtocolVersions)
+		return nil
+	},
+}
+
+func init() {
+	versionCmd.Flags().BoolVar(&versionJSONFlag, "json", false, "emit version info as JSON")
+	rootCmd.AddCommand(versionCmd)
+	rootCmd.SetVersionTemplate(fmt.Sprintf(
+		"devgen {{.Version}} (commit %s, built %s, %s)\n", buildCommit, buildDate, runtime.Version()))
+}